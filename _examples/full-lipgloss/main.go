@@ -46,10 +46,10 @@ func (m model) isInitialized() bool {
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		// Example of toggling mouse event tracking on/off.
+		// Example of toggling mouse event tracking on/off. Children receive a
+		// zone.MsgZoneEnabledChanged once toggled.
 		if msg.String() == "ctrl+e" {
-			zone.SetEnabled(!zone.Enabled())
-			return m, nil
+			return m, zone.SetEnabledCmd(!zone.Enabled())
 		}
 
 		if msg.String() == "ctrl+c" {
@@ -86,7 +86,6 @@ func (m model) propagate(msg tea.Msg) tea.Cmd {
 func (m model) View() tea.View {
 	var view tea.View
 	view.AltScreen = true
-	view.MouseMode = tea.MouseModeCellMotion
 
 	if !m.isInitialized() {
 		return view
@@ -94,8 +93,7 @@ func (m model) View() tea.View {
 
 	s := lipgloss.NewStyle().MaxHeight(m.height).MaxWidth(m.width)
 
-	// Wrap the main models view in [zone.Scan].
-	view.SetContent(zone.Scan(s.Render(
+	view.SetContent(s.Render(
 		lipgloss.JoinVertical(lipgloss.Top,
 			lipgloss.NewStyle().MarginBottom(1).Render(m.tabs.View()),
 			lipgloss.PlaceHorizontal(
@@ -108,7 +106,7 @@ func (m model) View() tea.View {
 			),
 			lipgloss.NewStyle().MarginTop(1).Render(m.history.View()),
 		),
-	)))
+	))
 	return view
 }
 
@@ -160,7 +158,10 @@ func main() {
 		},
 	}
 
-	p := tea.NewProgram(m)
+	// Wrap the main model with [zone.Wrap], which scans the view output for zones
+	// on every frame, and enables mouse tracking. Pressing ctrl+d toggles the zone
+	// inspector, which highlights the bounds of all zones.
+	p := tea.NewProgram(zone.Wrap(m, zone.WithInspectorKey("ctrl+d")))
 
 	if _, err := p.Run(); err != nil {
 		fmt.Println("error running program:", err) //nolint:forbidigo
@@ -2,13 +2,17 @@ module github.com/lrstanley/bubblezone/v2/examples
 
 go 1.24.2
 
-replace github.com/lrstanley/bubblezone/v2 => ../
+replace (
+	github.com/lrstanley/bubblezone/v2 => ../
+	github.com/lrstanley/bubblezone/v2/adapters => ../adapters
+)
 
 require (
 	charm.land/bubbles/v2 v2.0.0
 	charm.land/bubbletea/v2 v2.0.0
 	charm.land/lipgloss/v2 v2.0.0
 	github.com/lrstanley/bubblezone/v2 v2.0.0-alpha.3
+	github.com/lrstanley/bubblezone/v2/adapters v0.0.0-00010101000000-000000000000
 )
 
 require (
@@ -0,0 +1,32 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+// Package adapters wraps common bubbles components (list, viewport, textinput,
+// textarea and paginator), marking their items, pages and scrollbars as zones,
+// and translating mouse events within those zones into the native cursor and
+// selection methods of each component.
+//
+// Adapters embed the wrapped bubbles model, so all of its methods and fields are
+// still available. Like the components package, all adapters require a
+// *zone.Manager, and the root model must still scan the view, e.g. using
+// zone.Scan() or zone.Wrap().
+//
+// The adapters are a separate module from bubblezone, so that bubbles is only
+// required when they're used.
+package adapters
+
+import tea "charm.land/bubbletea/v2"
+
+// leftClick returns the mouse event of msg, if it's a left mouse button press.
+func leftClick(msg tea.Msg) (mouse tea.MouseClickMsg, ok bool) {
+	mouse, ok = msg.(tea.MouseClickMsg)
+	return mouse, ok && mouse.Button == tea.MouseLeft
+}
+
+// leftRelease returns the mouse event of msg, if it's a left mouse button
+// release.
+func leftRelease(msg tea.Msg) (mouse tea.MouseReleaseMsg, ok bool) {
+	mouse, ok = msg.(tea.MouseReleaseMsg)
+	return mouse, ok && mouse.Button == tea.MouseLeft
+}
@@ -0,0 +1,321 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package adapters
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"charm.land/bubbles/v2/list"
+	"charm.land/bubbles/v2/paginator"
+	tea "charm.land/bubbletea/v2"
+	zone "github.com/lrstanley/bubblezone/v2"
+)
+
+func scan(t *testing.T, manager *zone.Manager, view string) string {
+	t.Helper()
+	out := manager.Scan(view)
+	time.Sleep(50 * time.Millisecond)
+	return out
+}
+
+func press(x, y int) tea.MouseClickMsg {
+	return tea.MouseClickMsg{X: x, Y: y, Button: tea.MouseLeft}
+}
+
+func release(x, y int) tea.MouseReleaseMsg {
+	return tea.MouseReleaseMsg{X: x, Y: y, Button: tea.MouseLeft}
+}
+
+type item string
+
+func (i item) Title() string       { return string(i) }
+func (i item) Description() string { return "" }
+func (i item) FilterValue() string { return string(i) }
+
+func TestListDelegate(t *testing.T) {
+	zm := zone.New()
+	defer zm.Close()
+
+	base := list.NewDefaultDelegate()
+	base.ShowDescription = false
+	delegate := NewListDelegate(zm, base)
+
+	items := []list.Item{item("foo"), item("bar"), item("baz")}
+	l := list.New(items, delegate, 20, 20)
+
+	if out := scan(t, zm, l.View()); !strings.Contains(out, "baz") {
+		t.Fatalf("expected items to be rendered, got %q", out)
+	}
+
+	if got := l.Items()[2].FilterValue(); got != "baz" {
+		t.Errorf("got filter value %q, want it untouched", got)
+	}
+
+	baz := zm.Get(delegate.ItemID(2))
+	if baz.IsZero() {
+		t.Fatal("expected item to be marked")
+	}
+
+	l, _ = l.Update(release(baz.StartX, baz.StartY))
+	if l.Index() != 2 {
+		t.Errorf("got index %d, want 2 after clicking", l.Index())
+	}
+
+	l, _ = l.Update(tea.MouseWheelMsg{X: baz.StartX, Y: baz.StartY, Button: tea.MouseWheelUp})
+	if l.Index() != 1 {
+		t.Errorf("got index %d, want 1 after scrolling up", l.Index())
+	}
+
+	// Scrolling outside of the items shouldn't move the cursor.
+	l, _ = l.Update(tea.MouseWheelMsg{X: 30, Y: 30, Button: tea.MouseWheelUp})
+	if l.Index() != 1 {
+		t.Errorf("got index %d, want 1 after scrolling outside", l.Index())
+	}
+}
+
+func TestViewport(t *testing.T) {
+	zm := zone.New()
+	defer zm.Close()
+
+	lines := make([]string, 20)
+	for i := range lines {
+		lines[i] = "line " + strconv.Itoa(i)
+	}
+
+	v := NewViewport(zm)
+	v.SetWidth(10)
+	v.SetHeight(5)
+	v.SetContent(strings.Join(lines, "\n"))
+	v.Styles = ViewportStyles{TrackChar: "|", ThumbChar: "#", Scrollbar: true}
+
+	out := scan(t, zm, "\n"+v.View())
+	if got, want := strings.Split(out, "\n")[1], "line 0    #"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+
+	// Scrolling outside of the viewport is ignored.
+	v, _ = v.Update(tea.MouseWheelMsg{X: 0, Y: 0, Button: tea.MouseWheelDown})
+	if v.YOffset() != 0 {
+		t.Errorf("got offset %d, want 0", v.YOffset())
+	}
+
+	v, _ = v.Update(tea.MouseWheelMsg{X: 0, Y: 1, Button: tea.MouseWheelDown})
+	if v.YOffset() == 0 {
+		t.Error("expected viewport to scroll")
+	}
+
+	// Clicking the bottom of the scrollbar scrolls to the bottom, and dragging
+	// beyond the scrollbar (while captured) keeps scrolling.
+	v, _ = v.Update(press(10, 5))
+	if !v.AtBottom() {
+		t.Errorf("got offset %d, want bottom", v.YOffset())
+	}
+
+	v, _ = v.Update(tea.MouseMotionMsg{X: 20, Y: -5})
+	if !v.AtTop() {
+		t.Errorf("got offset %d, want top", v.YOffset())
+	}
+
+	v, _ = v.Update(release(20, -5))
+	if zm.Captured() != "" {
+		t.Error("expected capture to be released")
+	}
+}
+
+func TestTextInput(t *testing.T) {
+	zm := zone.New()
+	defer zm.Close()
+
+	ti := NewTextInput(zm)
+	ti.Prompt = "> "
+	ti.SetWidth(20)
+	ti.SetValue("héllo 世界")
+	ti.CursorStart()
+
+	_ = scan(t, zm, "  "+ti.View())
+
+	tests := []struct {
+		x    int
+		want int
+	}{
+		{4, 0},
+		{6, 2},
+		{10, 6},
+		{11, 6}, // Second cell of a wide character.
+		{12, 7},
+		{20, 8}, // Past the end of the value.
+	}
+
+	for _, test := range tests {
+		ti, _ = ti.Update(press(test.x, 0))
+		if got := ti.Position(); got != test.want {
+			t.Errorf("x=%d: got position %d, want %d", test.x, got, test.want)
+		}
+	}
+
+	if !ti.Focused() {
+		t.Error("expected input to be focused after clicking")
+	}
+
+	// Dragging selects text, and continues outside of the input while captured.
+	ti, _ = ti.Update(press(5, 0))
+	ti, _ = ti.Update(tea.MouseMotionMsg{X: 30, Y: 0})
+	ti, _ = ti.Update(release(30, 0))
+
+	if got := ti.SelectedText(); got != "éllo 世界" {
+		t.Errorf("got selection %q, want %q", got, "éllo 世界")
+	}
+
+	ti, _ = ti.Update(tea.KeyPressMsg{Code: tea.KeyLeft})
+	if _, _, ok := ti.Selection(); ok {
+		t.Error("expected selection to be cleared after a key press")
+	}
+
+	// Scrolled inputs take the offset into account.
+	ti.SetWidth(5)
+	ti.SetValue("abcdefghij")
+	ti.CursorEnd()
+	ti, _ = ti.Update(nil)
+
+	_ = scan(t, zm, ti.View())
+	ti, _ = ti.Update(press(3, 0))
+	if got := ti.Position(); got != 6 {
+		t.Errorf("scrolled: got position %d, want 6", got)
+	}
+
+	// Values of repeated runes look the same at any offset, so the offset must
+	// come from the scrolling state rather than the view. Moving the cursor left
+	// within the view doesn't scroll it.
+	ti.SetValue("aaaaaaaaaa")
+	ti.CursorEnd()
+	ti, _ = ti.Update(nil)
+	for range 3 {
+		ti, _ = ti.Update(tea.KeyPressMsg{Code: tea.KeyLeft})
+	}
+
+	_ = scan(t, zm, ti.View())
+	ti, _ = ti.Update(press(3, 0))
+	if got := ti.Position(); got != 6 {
+		t.Errorf("scrolled repeated runes: got position %d, want 6", got)
+	}
+
+	// Placeholders aren't part of the value.
+	ti.SetValue("")
+	ti.Placeholder = "type here"
+	_ = scan(t, zm, ti.View())
+	ti, _ = ti.Update(press(6, 0))
+	if got := ti.Position(); got != 0 {
+		t.Errorf("placeholder: got position %d, want 0", got)
+	}
+}
+
+func TestTextArea(t *testing.T) {
+	zm := zone.New()
+	defer zm.Close()
+
+	ta := NewTextArea(zm)
+	ta.ShowLineNumbers = false
+	ta.Prompt = "> "
+	ta.SetWidth(12)
+	ta.SetHeight(4)
+	ta.SetValue("foo\nhello world foo\nbar")
+	ta.MoveToBegin()
+
+	_ = scan(t, zm, ta.View())
+
+	tests := []struct {
+		x, y      int
+		line, col int
+	}{
+		{4, 0, 0, 2},
+		{3, 1, 1, 1},
+		{2, 2, 1, 6}, // Soft wrapped.
+		{11, 3, 2, 3},
+		{2, 0, 0, 0},
+	}
+
+	for _, test := range tests {
+		ta, _ = ta.Update(press(test.x, test.y))
+		if line, col := ta.Line(), ta.Column(); line != test.line || col != test.col {
+			t.Errorf("(%d, %d): got line %d col %d, want line %d col %d", test.x, test.y, line, col, test.line, test.col)
+		}
+	}
+
+	// Dragging selects text across lines, in either direction.
+	ta, _ = ta.Update(press(4, 0))
+	if _, _, ok := ta.Selection(); ok {
+		t.Error("expected no selection after a press")
+	}
+
+	ta, _ = ta.Update(tea.MouseMotionMsg{X: 11, Y: 3, Button: tea.MouseLeft})
+	ta, _ = ta.Update(release(3, 1))
+
+	from, to, ok := ta.Selection()
+	if !ok || from != (zone.CaretPosition{Line: 0, Index: 2}) || to != (zone.CaretPosition{Line: 1, Index: 1}) {
+		t.Errorf("got selection %+v to %+v (%v)", from, to, ok)
+	}
+	if got := ta.SelectedText(); got != "o\nh" {
+		t.Errorf("got selected text %q, want %q", got, "o\nh")
+	}
+	if zm.Captured() != "" {
+		t.Errorf("got captured %q, want none after release", zm.Captured())
+	}
+
+	// Dragging past the bottom moves to the last line.
+	_ = scan(t, zm, ta.View())
+	ta, _ = ta.Update(press(11, 3))
+	ta, _ = ta.Update(release(2, 10))
+	if got := ta.SelectedText(); got != "bar" {
+		t.Errorf("got selected text %q, want %q", got, "bar")
+	}
+
+	_ = scan(t, zm, ta.View())
+	ta, _ = ta.Update(press(4, 0))
+	ta, _ = ta.Update(release(20, 10))
+	if got, want := ta.SelectedText(), "o\nhello world foo\nbar"; got != want {
+		t.Errorf("got selected text %q, want %q", got, want)
+	}
+
+	ta, _ = ta.Update(tea.KeyPressMsg{Code: tea.KeyRight})
+	if _, _, ok := ta.Selection(); ok {
+		t.Error("expected a key press to clear the selection")
+	}
+}
+
+func TestPaginator(t *testing.T) {
+	zm := zone.New()
+	defer zm.Close()
+
+	p := NewPaginator(zm, paginator.WithTotalPages(3))
+	p.Type = paginator.Dots
+	p.ActiveDot, p.InactiveDot = "o", "."
+
+	if got := scan(t, zm, p.View()); got != "o.." {
+		t.Fatalf("got %q, want %q", got, "o..")
+	}
+
+	p, _ = p.Update(release(2, 0))
+	if p.Page != 2 {
+		t.Errorf("got page %d, want 2", p.Page)
+	}
+
+	p.Type = paginator.Arabic
+	if got := scan(t, zm, p.View()); got != "3/3" {
+		t.Fatalf("got %q, want %q", got, "3/3")
+	}
+
+	p, _ = p.Update(release(0, 0))
+	if p.Page != 1 {
+		t.Errorf("got page %d, want 1", p.Page)
+	}
+
+	p, _ = p.Update(release(2, 0))
+	if p.Page != 2 {
+		t.Errorf("got page %d, want 2", p.Page)
+	}
+}
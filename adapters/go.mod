@@ -0,0 +1,35 @@
+module github.com/lrstanley/bubblezone/v2/adapters
+
+go 1.24.2
+
+// Downstream builds ignore this, and use the required version below, which must
+// be bumped to a release of the root module with all APIs used by the adapters
+// before tagging a release (see "adapters-require" in the Makefile).
+replace github.com/lrstanley/bubblezone/v2 => ../
+
+require (
+	charm.land/bubbles/v2 v2.0.0
+	charm.land/bubbletea/v2 v2.0.0
+	charm.land/lipgloss/v2 v2.0.0
+	github.com/charmbracelet/x/ansi v0.11.6
+	github.com/lrstanley/bubblezone/v2 v2.0.0-alpha.3
+	github.com/mattn/go-runewidth v0.0.20
+	github.com/rivo/uniseg v0.4.7
+)
+
+require (
+	github.com/atotto/clipboard v0.1.4 // indirect
+	github.com/charmbracelet/colorprofile v0.4.2 // indirect
+	github.com/charmbracelet/ultraviolet v0.0.0-20260223171050-89c142e4aa73 // indirect
+	github.com/charmbracelet/x/term v0.2.2 // indirect
+	github.com/charmbracelet/x/termios v0.1.1 // indirect
+	github.com/charmbracelet/x/windows v0.2.2 // indirect
+	github.com/clipperhouse/displaywidth v0.11.0 // indirect
+	github.com/clipperhouse/uax29/v2 v2.7.0 // indirect
+	github.com/lucasb-eyer/go-colorful v1.3.0 // indirect
+	github.com/muesli/cancelreader v0.2.2 // indirect
+	github.com/sahilm/fuzzy v0.1.1 // indirect
+	github.com/xo/terminfo v0.0.0-20220910002029-abceb7e1c41e // indirect
+	golang.org/x/sync v0.19.0 // indirect
+	golang.org/x/sys v0.41.0 // indirect
+)
@@ -0,0 +1,98 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package adapters
+
+import (
+	"io"
+	"strconv"
+	"strings"
+
+	"charm.land/bubbles/v2/list"
+	tea "charm.land/bubbletea/v2"
+	zone "github.com/lrstanley/bubblezone/v2"
+)
+
+// ListDelegate wraps a list.ItemDelegate, marking each rendered item as a zone.
+// Unlike marking the title of each item, the items themselves (and as such,
+// their FilterValue()) are left untouched, so filtering still works.
+//
+// Clicking an item selects it, and using the mouse wheel over the items moves
+// the cursor. Use it as the delegate of a list.Model:
+//
+//	l := list.New(items, adapters.NewListDelegate(manager, list.NewDefaultDelegate()), 0, 0)
+type ListDelegate struct {
+	list.ItemDelegate
+
+	manager *zone.Manager
+	id      string
+}
+
+// NewListDelegate returns a new ListDelegate wrapping delegate.
+func NewListDelegate(manager *zone.Manager, delegate list.ItemDelegate) ListDelegate {
+	return ListDelegate{
+		ItemDelegate: delegate,
+		manager:      manager,
+		id:           manager.NewPrefix(),
+	}
+}
+
+// ID returns the unique ID of the delegate, which is used as the prefix of the
+// zone ID of each item.
+func (d ListDelegate) ID() string {
+	return d.id
+}
+
+// ItemID returns the zone ID of the item at the provided index, within the
+// visible items of the list (see list.Model.VisibleItems()).
+func (d ListDelegate) ItemID(index int) string {
+	return d.id + strconv.Itoa(index)
+}
+
+// Render renders the item using the wrapped delegate, marking it as a zone.
+func (d ListDelegate) Render(w io.Writer, m list.Model, index int, item list.Item) {
+	var b strings.Builder
+	d.ItemDelegate.Render(&b, m, index, item)
+	_, _ = io.WriteString(w, d.manager.Mark(d.ItemID(index), b.String()))
+}
+
+// Update selects the clicked item, and moves the cursor when using the mouse
+// wheel over the items, before passing the message to the wrapped delegate.
+func (d ListDelegate) Update(msg tea.Msg, m *list.Model) tea.Cmd {
+	switch msg := msg.(type) {
+	case tea.MouseReleaseMsg:
+		if msg.Button != tea.MouseLeft {
+			break
+		}
+
+		if index, ok := d.itemAt(msg, m); ok {
+			m.Select(index)
+		}
+	case tea.MouseWheelMsg:
+		if _, ok := d.itemAt(msg, m); !ok {
+			break
+		}
+
+		switch msg.Button { //nolint:exhaustive
+		case tea.MouseWheelUp:
+			m.CursorUp()
+		case tea.MouseWheelDown:
+			m.CursorDown()
+		}
+	}
+
+	return d.ItemDelegate.Update(msg, m)
+}
+
+// itemAt returns the index of the item on the current page under the mouse
+// event, if any.
+func (d ListDelegate) itemAt(msg tea.MouseMsg, m *list.Model) (index int, ok bool) {
+	start, end := m.Paginator.GetSliceBounds(len(m.VisibleItems()))
+	for i := start; i < end; i++ {
+		if d.manager.Get(d.ItemID(i)).InBounds(msg) {
+			return i, true
+		}
+	}
+	return -1, false
+}
@@ -0,0 +1,88 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package adapters
+
+import (
+	"strconv"
+	"strings"
+
+	"charm.land/bubbles/v2/paginator"
+	tea "charm.land/bubbletea/v2"
+	zone "github.com/lrstanley/bubblezone/v2"
+)
+
+// Paginator wraps a paginator.Model, marking it as a zone. When using the dots
+// type, each dot is marked as a zone, and clicking a dot goes to its page. When
+// using the arabic type, clicking the left half goes to the previous page, and
+// clicking the right half goes to the next page.
+type Paginator struct {
+	paginator.Model
+
+	manager *zone.Manager
+	id      string
+}
+
+// NewPaginator returns a new paginator, with the provided paginator options.
+func NewPaginator(manager *zone.Manager, opts ...paginator.Option) Paginator {
+	return Paginator{
+		Model:   paginator.New(opts...),
+		manager: manager,
+		id:      manager.NewPrefix(),
+	}
+}
+
+// ID returns the unique ID of the paginator, which is also used as its zone ID.
+func (p Paginator) ID() string {
+	return p.id
+}
+
+// PageID returns the zone ID of the dot of the provided page.
+func (p Paginator) PageID(page int) string {
+	return p.id + "p" + strconv.Itoa(page)
+}
+
+// Update changes the page when clicked, and passes all messages to the wrapped
+// paginator.
+func (p Paginator) Update(msg tea.Msg) (Paginator, tea.Cmd) {
+	if mouse, ok := leftRelease(msg); ok && p.manager.Get(p.id).InBounds(mouse) {
+		switch p.Type {
+		case paginator.Dots:
+			for page := range p.TotalPages {
+				if p.manager.Get(p.PageID(page)).InBounds(mouse) {
+					p.Page = page
+					break
+				}
+			}
+		default:
+			bounds := p.manager.Get(p.id)
+			if x, _ := bounds.Pos(mouse); x < (bounds.EndX-bounds.StartX+1)/2 {
+				p.PrevPage()
+			} else {
+				p.NextPage()
+			}
+		}
+	}
+
+	var cmd tea.Cmd
+	p.Model, cmd = p.Model.Update(msg)
+	return p, cmd
+}
+
+// View renders the paginator.
+func (p Paginator) View() string {
+	if p.Type != paginator.Dots {
+		return p.manager.Mark(p.id, p.Model.View())
+	}
+
+	var b strings.Builder
+	for page := range p.TotalPages {
+		dot := p.InactiveDot
+		if page == p.Page {
+			dot = p.ActiveDot
+		}
+		b.WriteString(p.manager.Mark(p.PageID(page), dot))
+	}
+	return p.manager.Mark(p.id, b.String())
+}
@@ -0,0 +1,200 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package adapters
+
+import (
+	"strings"
+
+	"charm.land/bubbles/v2/textarea"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	zone "github.com/lrstanley/bubblezone/v2"
+)
+
+// TextArea wraps a textarea.Model, marking it as a zone. Clicking the text area
+// focuses it, and moves the cursor to the clicked line and column, taking soft
+// wrapping and scrolling into account. Dragging the mouse selects text across
+// lines (see Selection()), moving the cursor with the mouse, and scrolling the
+// text area when dragging past its top or bottom. As textarea.Model has no
+// concept of a selection, the selection isn't highlighted, and is cleared by
+// any key press.
+type TextArea struct {
+	textarea.Model
+
+	manager  *zone.Manager
+	id       string
+	anchor   *zone.CaretPosition // Start of the selection, if any.
+	dragging bool
+}
+
+// NewTextArea returns a new text area.
+func NewTextArea(manager *zone.Manager) TextArea {
+	return TextArea{
+		Model:   textarea.New(),
+		manager: manager,
+		id:      manager.NewPrefix(),
+	}
+}
+
+// ID returns the unique ID of the text area, which is also used as its zone ID.
+func (t TextArea) ID() string {
+	return t.id
+}
+
+// Update focuses the text area and positions the cursor when clicked, selects
+// text when dragging, and passes all messages to the wrapped text area.
+func (t TextArea) Update(msg tea.Msg) (TextArea, tea.Cmd) {
+	var cmds []tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.MouseClickMsg:
+		if mouse, ok := leftClick(msg); ok && t.manager.Get(t.id).InBounds(mouse) {
+			x, y := t.manager.Get(t.id).Pos(mouse)
+			t.moveTo(x, y)
+
+			t.anchor = &zone.CaretPosition{Line: t.Line(), Index: t.Column()}
+			t.dragging = true
+			t.manager.Capture(t.id)
+
+			if !t.Focused() {
+				cmds = append(cmds, t.Focus())
+			}
+		}
+	case tea.MouseMotionMsg, tea.MouseReleaseMsg:
+		if !t.dragging {
+			break
+		}
+
+		if x, y, ok := t.manager.Get(t.id).RelativePos(msg.(tea.MouseMsg)); ok {
+			t.moveTo(x, y)
+		}
+
+		if _, ok := msg.(tea.MouseReleaseMsg); ok {
+			t.dragging = false
+			if t.manager.Captured() == t.id {
+				t.manager.Release()
+			}
+		}
+	case tea.KeyPressMsg:
+		t.anchor = nil
+	}
+
+	var cmd tea.Cmd
+	t.Model, cmd = t.Model.Update(msg)
+	return t, tea.Batch(append(cmds, cmd)...)
+}
+
+// Selection returns the range of text selected by dragging the mouse, as
+// positions within the value (the line, and the rune index within the line).
+// The returned range is ordered, with from being inclusive, and to exclusive. If
+// no text is selected, ok is false.
+func (t TextArea) Selection() (from, to zone.CaretPosition, ok bool) {
+	if t.anchor == nil {
+		return from, to, false
+	}
+
+	from, to = *t.anchor, zone.CaretPosition{Line: t.Line(), Index: t.Column()}
+	if to.Before(from) {
+		from, to = to, from
+	}
+	return from, to, from != to
+}
+
+// SelectedText returns the text selected by dragging the mouse, if any. See
+// Selection().
+func (t TextArea) SelectedText() string {
+	from, to, ok := t.Selection()
+	if !ok {
+		return ""
+	}
+
+	lines := strings.Split(t.Value(), "\n")
+	if from.Line >= len(lines) {
+		return ""
+	}
+	to.Line = min(to.Line, len(lines)-1)
+
+	selected := make([]string, 0, to.Line-from.Line+1)
+	for line := from.Line; line <= to.Line; line++ {
+		runes := []rune(lines[line])
+
+		start, end := 0, len(runes)
+		if line == from.Line {
+			start = min(from.Index, end)
+		}
+		if line == to.Line {
+			end = max(start, min(to.Index, end))
+		}
+
+		selected = append(selected, string(runes[start:end]))
+	}
+
+	return strings.Join(selected, "\n")
+}
+
+// moveTo moves the cursor to the provided cell, relative to the rendered text
+// area.
+func (t *TextArea) moveTo(x, y int) {
+	base := t.Styles().Blurred.Base
+	if t.Focused() {
+		base = t.Styles().Focused.Base
+	}
+	left, top := frame(base)
+
+	// Move a line at a time, as lines may be soft wrapped, stopping early if the
+	// cursor can't move any further.
+	_, cur := t.cursor()
+	for delta := y - top - cur; delta != 0; {
+		line, row := t.Line(), t.LineInfo().RowOffset
+		if delta > 0 {
+			t.CursorDown()
+			delta--
+		} else {
+			t.CursorUp()
+			delta++
+		}
+
+		if line == t.Line() && row == t.LineInfo().RowOffset {
+			break
+		}
+	}
+
+	info := t.LineInfo()
+	t.SetCursorColumn(info.StartColumn)
+	textX, _ := t.cursor()
+
+	runes := []rune(strings.Split(t.Value(), "\n")[t.Line()])
+	start := min(info.StartColumn, len(runes))
+	end := min(len(runes), start+info.Width)
+	t.SetCursorColumn(zone.Caret(
+		string(runes[start:end]), x-left-textX,
+		zone.WithCaretOffset(start),
+		zone.WithCaretLimit(end),
+		zone.WithCaretWidthFunc(t.manager.Width),
+	))
+}
+
+// cursor returns the position of the cursor, relative to the text area without
+// its frame (i.e. including the prompt and line numbers).
+func (t TextArea) cursor() (x, y int) {
+	probe := t.Model
+	probe.SetVirtualCursor(false)
+	_ = probe.Focus()
+
+	left, top := frame(probe.Styles().Focused.Base)
+	c := probe.Cursor()
+	return c.X - left, c.Y - top
+}
+
+// frame returns the size of the left and top frame of style.
+func frame(style lipgloss.Style) (left, top int) {
+	return style.GetMarginLeft() + style.GetPaddingLeft() + style.GetBorderLeftSize(),
+		style.GetMarginTop() + style.GetPaddingTop() + style.GetBorderTopSize()
+}
+
+// View renders the text area.
+func (t TextArea) View() string {
+	return t.manager.Mark(t.id, t.Model.View())
+}
@@ -0,0 +1,179 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package adapters
+
+import (
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+	zone "github.com/lrstanley/bubblezone/v2"
+	"github.com/mattn/go-runewidth"
+	"github.com/rivo/uniseg"
+)
+
+// TextInput wraps a textinput.Model, marking it as a zone. Clicking the input
+// focuses it, and moves the cursor to the clicked character, taking wide
+// characters, horizontal scrolling and placeholders into account. Dragging with
+// the left mouse button selects a range of text (see Selection()).
+type TextInput struct {
+	textinput.Model
+
+	manager  *zone.Manager
+	id       string
+	anchor   int // Index the selection started at.
+	dragging bool
+
+	// Runes scrolled out of view to the left and the end of the visible runes,
+	// which aren't exposed by the text input. See scroll().
+	offset      int
+	offsetRight int
+}
+
+// NewTextInput returns a new text input.
+func NewTextInput(manager *zone.Manager) TextInput {
+	return TextInput{
+		Model:   textinput.New(),
+		manager: manager,
+		id:      manager.NewPrefix(),
+		anchor:  -1,
+	}
+}
+
+// ID returns the unique ID of the text input, which is also used as its zone ID.
+func (t TextInput) ID() string {
+	return t.id
+}
+
+// Selection returns the range of runes selected by dragging the mouse, with
+// start being inclusive and end exclusive. If nothing is selected, ok is false.
+// The selection is cleared when a key is pressed, or the input is clicked again.
+func (t TextInput) Selection() (start, end int, ok bool) {
+	if t.anchor < 0 || t.anchor == t.Position() {
+		return 0, 0, false
+	}
+	return min(t.anchor, t.Position()), max(t.anchor, t.Position()), true
+}
+
+// SelectedText returns the text selected by dragging the mouse, if any.
+func (t TextInput) SelectedText() string {
+	start, end, ok := t.Selection()
+	if !ok {
+		return ""
+	}
+	return string([]rune(t.Value())[start:end])
+}
+
+// Update focuses the input and positions the cursor when clicked, selects text
+// when dragging, and passes all messages to the wrapped text input.
+func (t TextInput) Update(msg tea.Msg) (TextInput, tea.Cmd) {
+	var cmds []tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.MouseClickMsg:
+		if msg.Button != tea.MouseLeft || !t.manager.Get(t.id).InBounds(msg) {
+			break
+		}
+
+		if !t.Focused() {
+			cmds = append(cmds, t.Focus())
+		}
+
+		t.SetCursor(t.caret(msg))
+		t.anchor = t.Position()
+		t.dragging = true
+		t.manager.Capture(t.id)
+	case tea.MouseMotionMsg:
+		if t.dragging {
+			t.SetCursor(t.caret(msg))
+		}
+	case tea.MouseReleaseMsg:
+		if t.dragging && msg.Button == tea.MouseLeft {
+			t.dragging = false
+			t.manager.Release()
+		}
+	case tea.KeyPressMsg:
+		t.anchor = -1
+	}
+
+	var cmd tea.Cmd
+	t.Model, cmd = t.Model.Update(msg)
+	t.scroll()
+	return t, tea.Batch(append(cmds, cmd)...)
+}
+
+// caret returns the index of the rune under the mouse event. As the state of
+// the input hasn't changed since it was last rendered, the view is rendered
+// again to find what was under the mouse.
+func (t TextInput) caret(msg tea.MouseMsg) int {
+	// Catch up with changes made outside of Update() (e.g. SetValue()).
+	t.scroll()
+
+	return t.manager.Get(t.id).Caret(
+		ansi.Strip(t.Model.View()), msg,
+		zone.WithCaretPrefix(t.promptWidth()),
+		zone.WithCaretOffset(t.offset),
+		zone.WithCaretLimit(len([]rune(t.Value()))),
+	)
+}
+
+// promptWidth returns the width of the prompt, in cells.
+func (t TextInput) promptWidth() int {
+	probe := t.Model
+	probe.SetVirtualCursor(false)
+	probe.CursorStart()
+	_ = probe.Focus()
+	return probe.Cursor().X
+}
+
+// scroll updates the number of runes scrolled out of view to the left, from the
+// cursor position and the width of the input, using the same rules as the text
+// input: the view only scrolls once the cursor moves past either edge of it.
+func (t *TextInput) scroll() {
+	value := []rune(t.Value())
+	width := t.Width()
+
+	if width <= 0 || uniseg.StringWidth(string(value)) <= width {
+		t.offset, t.offsetRight = 0, len(value)
+		return
+	}
+
+	pos := t.Position()
+	t.offsetRight = min(t.offsetRight, len(value))
+
+	switch {
+	case pos < t.offset:
+		t.offset = pos
+
+		w, i := 0, 0
+		runes := value[t.offset:]
+		for i < len(runes) && w <= width {
+			w += runewidth.RuneWidth(runes[i])
+			if w <= width+1 {
+				i++
+			}
+		}
+
+		t.offsetRight = t.offset + i
+	case pos >= t.offsetRight:
+		t.offsetRight = pos
+
+		w := 0
+		runes := value[:t.offsetRight]
+		i := len(runes) - 1
+		for i > 0 && w < width {
+			w += runewidth.RuneWidth(runes[i])
+			if w <= width {
+				i--
+			}
+		}
+
+		t.offset = t.offsetRight - (len(runes) - 1 - i)
+	}
+}
+
+// View renders the text input.
+func (t TextInput) View() string {
+	return t.manager.Mark(t.id, t.Model.View())
+}
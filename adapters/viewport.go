@@ -0,0 +1,166 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package adapters
+
+import (
+	"strings"
+
+	"charm.land/bubbles/v2/viewport"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	zone "github.com/lrstanley/bubblezone/v2"
+)
+
+// ViewportStyles are the styles used to render the scrollbar of a viewport.
+type ViewportStyles struct {
+	Track     lipgloss.Style
+	Thumb     lipgloss.Style
+	TrackChar string
+	ThumbChar string
+	Scrollbar bool // Render a scrollbar to the right of the viewport.
+}
+
+// DefaultViewportStyles returns the default styles of a viewport.
+func DefaultViewportStyles() ViewportStyles {
+	return ViewportStyles{
+		Track:     lipgloss.NewStyle().Foreground(lipgloss.Color("#383838")),
+		Thumb:     lipgloss.NewStyle().Foreground(lipgloss.Color("#7D56F4")),
+		TrackChar: "│",
+		ThumbChar: "┃",
+		Scrollbar: true,
+	}
+}
+
+// Viewport wraps a viewport.Model, marking it (and its scrollbar) as a zone.
+// The mouse wheel only scrolls the viewport while the mouse is over it, and
+// clicking or dragging the scrollbar scrolls to the matching position.
+//
+// Note that the scrollbar is rendered in addition to the width of the viewport.
+type Viewport struct {
+	viewport.Model
+
+	manager  *zone.Manager
+	id       string
+	dragging bool
+
+	Styles ViewportStyles
+}
+
+// NewViewport returns a new viewport, with the provided viewport options.
+func NewViewport(manager *zone.Manager, opts ...viewport.Option) Viewport {
+	return Viewport{
+		Model:   viewport.New(opts...),
+		manager: manager,
+		id:      manager.NewPrefix(),
+		Styles:  DefaultViewportStyles(),
+	}
+}
+
+// ID returns the unique ID of the viewport, which is also used as its zone ID.
+func (v Viewport) ID() string {
+	return v.id
+}
+
+// ScrollbarID returns the zone ID of the scrollbar.
+func (v Viewport) ScrollbarID() string {
+	return v.id + "s"
+}
+
+// Update handles mouse events for scrolling, and passes all other messages to
+// the wrapped viewport.
+func (v Viewport) Update(msg tea.Msg) (Viewport, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.MouseWheelMsg:
+		if !v.manager.Get(v.id).InBounds(msg) {
+			return v, nil
+		}
+	case tea.MouseClickMsg:
+		if msg.Button != tea.MouseLeft || !v.manager.Get(v.ScrollbarID()).InBounds(msg) {
+			return v, nil
+		}
+
+		v.dragging = true
+		v.manager.Capture(v.ScrollbarID())
+		v.scrollTo(msg)
+		return v, nil
+	case tea.MouseMotionMsg:
+		if v.dragging {
+			v.scrollTo(msg)
+		}
+		return v, nil
+	case tea.MouseReleaseMsg:
+		if v.dragging && msg.Button == tea.MouseLeft {
+			v.dragging = false
+			v.manager.Release()
+		}
+		return v, nil
+	}
+
+	var cmd tea.Cmd
+	v.Model, cmd = v.Model.Update(msg)
+	return v, cmd
+}
+
+// scrollTo scrolls to the position matching the mouse event on the scrollbar.
+func (v *Viewport) scrollTo(msg tea.MouseMsg) {
+	bar := v.manager.Get(v.ScrollbarID())
+	if bar.IsZero() {
+		return
+	}
+
+	height := bar.EndY - bar.StartY + 1
+	if height <= 1 {
+		return
+	}
+
+	_, y, _ := bar.RelativePos(msg)
+	maxOffset := max(0, v.TotalLineCount()-v.VisibleLineCount())
+	v.SetYOffset((max(0, y)*maxOffset + (height-1)/2) / (height - 1))
+}
+
+// scrollbar renders the scrollbar, with the provided height.
+func (v Viewport) scrollbar(height int) string {
+	visible, total := v.VisibleLineCount(), v.TotalLineCount()
+
+	size, top := height, 0
+	if total > visible && total > 0 {
+		size = max(1, height*visible/total)
+		if maxOffset := total - visible; maxOffset > 0 {
+			top = (v.YOffset()*(height-size) + maxOffset/2) / maxOffset
+		}
+	}
+
+	lines := make([]string, height)
+	for i := range lines {
+		if i >= top && i < top+size {
+			lines[i] = v.Styles.Thumb.Render(v.Styles.ThumbChar)
+		} else {
+			lines[i] = v.Styles.Track.Render(v.Styles.TrackChar)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// View renders the viewport, and its scrollbar if enabled.
+func (v Viewport) View() string {
+	content := v.Model.View()
+	if v.Styles.Scrollbar && content != "" {
+		content = lipgloss.JoinHorizontal(
+			lipgloss.Top,
+			content,
+			v.manager.MarkWith(v.ScrollbarID(), v.scrollbar(lipgloss.Height(content)), zone.WithNonSelectable()),
+		)
+	}
+
+	return v.manager.MarkWith(v.id, content, zone.WithScrollable(func(delta int, horizontal bool) bool {
+		if horizontal {
+			return false
+		}
+		if delta < 0 {
+			return !v.AtTop()
+		}
+		return !v.AtBottom()
+	}))
+}
@@ -0,0 +1,129 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package zone
+
+import (
+	"slices"
+	"sort"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// Capture captures the mouse for the zone with the provided ID, routing all
+// mouse events to it (regardless of the bounds of the zone) until Release() is
+// called. This is useful for things like sliders and scrollbars, where the
+// mouse may be dragged outside of the zone after being pressed within it.
+//
+// While captured, ZoneInfo.Pos() and ZoneInfo.RelativePos() will return
+// coordinates relative to the zone even if the mouse event is outside of the
+// zone, which means they may be negative, or larger than the zone itself.
+//
+// Wrapped models (see Wrap()) automatically capture the innermost zone under
+// a mouse press (after the press is sent to all zones in bounds), and release it
+// when the mouse button is released. The release is sent to all zones in bounds,
+// as well as the captured zone. As the release event may be lost (e.g. if
+// the mouse button is released outside of the terminal), wrapped models also
+// release the capture when the terminal loses focus (if focus reporting is
+// enabled), and release automatic captures on the next press, or mouse motion
+// without a button held. Captures started with Capture() are only released by
+// the release of the mouse button, or when the terminal loses focus, so call
+// Release() to recover from a lost release event in other cases.
+func (m *Manager) Capture(id string) {
+	m.captureMu.Lock()
+	m.captured = id
+	m.autoCaptured = ""
+	m.captureMu.Unlock()
+}
+
+// Release releases the mouse capture started with Capture(), if any.
+func (m *Manager) Release() {
+	m.Capture("")
+}
+
+// Captured returns the ID of the zone which currently has the mouse captured,
+// or an empty string if no zone has the mouse captured.
+func (m *Manager) Captured() (id string) {
+	m.captureMu.RLock()
+	id = m.captured
+	m.captureMu.RUnlock()
+	return id
+}
+
+// hitZones returns the zones which a mouse event should be routed to. If a zone
+// has the mouse captured, only that zone is returned, otherwise all zones in the
+// bounds of the mouse event are returned. The release of an automatic capture
+// (see capturePress()) is routed to all zones in bounds, as they all received
+// the press, as well as the captured zone if it is out of bounds.
+func (m *Manager) hitZones(mouse tea.MouseMsg) []*ZoneInfo {
+	m.captureMu.RLock()
+	id, auto := m.captured, m.captured != "" && m.captured == m.autoCaptured
+	m.captureMu.RUnlock()
+
+	if id == "" {
+		return m.findInBounds(mouse)
+	}
+
+	zone := m.Get(id)
+	if zone.IsZero() {
+		return m.findInBounds(mouse)
+	}
+
+	if _, ok := mouse.(tea.MouseReleaseMsg); !ok || !auto {
+		return []*ZoneInfo{zone}
+	}
+
+	zones := m.findInBounds(mouse)
+	i := sort.Search(len(zones), func(i int) bool { return zones[i].name >= id })
+	if i < len(zones) && zones[i].name == id {
+		return zones
+	}
+	return slices.Insert(zones, i, zone)
+}
+
+// innermost returns the zones sorted by how deeply they are nested, innermost
+// first. Zones of the same depth keep their existing order.
+func innermost(zones []*ZoneInfo) []*ZoneInfo {
+	sort.SliceStable(zones, func(i, j int) bool {
+		return zones[i].depth > zones[j].depth
+	})
+	return zones
+}
+
+// capturePress captures the innermost zone under a mouse press, if no zone
+// already has the mouse captured.
+func (m *Manager) capturePress(mouse tea.MouseMsg) {
+	if _, ok := mouse.(tea.MouseClickMsg); !ok || m.Captured() != "" {
+		return
+	}
+
+	if zones := innermost(m.findInBounds(mouse)); len(zones) > 0 {
+		m.captureMu.Lock()
+		m.captured = zones[0].name
+		m.autoCaptured = zones[0].name
+		m.captureMu.Unlock()
+	}
+}
+
+// releaseStale releases the zone captured by capturePress(), if the mouse event
+// shows its release event was lost: a new press, or motion without a mouse
+// button held.
+func (m *Manager) releaseStale(mouse tea.MouseMsg) {
+	switch mouse := mouse.(type) {
+	case tea.MouseClickMsg:
+	case tea.MouseMotionMsg:
+		if mouse.Button != tea.MouseNone {
+			return
+		}
+	default:
+		return
+	}
+
+	m.captureMu.Lock()
+	if m.autoCaptured != "" && m.captured == m.autoCaptured {
+		m.captured = ""
+	}
+	m.autoCaptured = ""
+	m.captureMu.Unlock()
+}
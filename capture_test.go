@@ -0,0 +1,205 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package zone
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+func TestCapture(t *testing.T) {
+	zm := New()
+	defer zm.Close()
+
+	// Starts at X:4, Y:2, ends at X:12, Y:3.
+	_ = zm.Scan("test\nfoo\naaa " + zm.Mark("foo", "bar\ntest123456789") + " " + zm.Mark("bar", "aaa") + "\nbaz")
+	time.Sleep(100 * time.Millisecond)
+
+	xy := zm.Get("foo")
+	if xy.IsZero() {
+		t.Fatal("id not found")
+	}
+
+	if x, y := xy.Pos(tea.MouseMotionMsg{X: 0, Y: 0}); x != -1 || y != -1 {
+		t.Errorf("got %d,%d, want -1,-1", x, y)
+	}
+
+	zm.Capture("foo")
+	if id := zm.Captured(); id != "foo" {
+		t.Errorf("got captured %q, want %q", id, "foo")
+	}
+
+	if x, y, ok := xy.RelativePos(tea.MouseMotionMsg{X: 0, Y: 0}); !ok || x != -4 || y != -2 {
+		t.Errorf("got %d,%d (%v), want -4,-2", x, y, ok)
+	}
+
+	if x, y, ok := xy.RelativePos(tea.MouseMotionMsg{X: 20, Y: 5}); !ok || x != 16 || y != 3 {
+		t.Errorf("got %d,%d (%v), want 16,3", x, y, ok)
+	}
+
+	if x, y := xy.Pos(tea.MouseMotionMsg{X: 0, Y: 0}); x != -4 || y != -2 {
+		t.Errorf("got %d,%d, want -4,-2 while captured", x, y)
+	}
+
+	if x, y := xy.Pos(tea.MouseMotionMsg{X: 20, Y: 5}); x != 16 || y != 3 {
+		t.Errorf("got %d,%d, want 16,3 while captured", x, y)
+	}
+
+	// Events over other zones should still be routed to the captured zone.
+	zones := zm.hitZones(tea.MouseMotionMsg{X: 14, Y: 3})
+	if len(zones) != 1 || zones[0].ID() != "foo" {
+		t.Errorf("got %d zones, want only %q", len(zones), "foo")
+	}
+
+	zm.Release()
+	if id := zm.Captured(); id != "" {
+		t.Errorf("got captured %q, want none", id)
+	}
+
+	if _, _, ok := xy.RelativePos(tea.MouseMotionMsg{X: 0, Y: 0}); ok {
+		t.Error("expected position outside of the zone not to be tracked after release")
+	}
+
+	if x, y := xy.Pos(tea.MouseMotionMsg{X: 0, Y: 0}); x != -1 || y != -1 {
+		t.Errorf("got %d,%d, want -1,-1 after release", x, y)
+	}
+}
+
+func TestWrapAutoCapture(t *testing.T) {
+	wrapped := Wrap(testWrapModel{})
+	_ = wrapped.View()
+	time.Sleep(100 * time.Millisecond)
+
+	wrapped, _ = wrapped.Update(tea.MouseClickMsg{X: 5, Y: 2})
+	if id := Captured(); id != "foo" {
+		t.Fatalf("got captured %q, want %q", id, "foo")
+	}
+
+	// Dragging outside of the zone should still be routed to the zone.
+	wrapped, _ = wrapped.Update(tea.MouseMotionMsg{X: 1, Y: 0, Button: tea.MouseLeft})
+	wrapped, _ = wrapped.Update(tea.MouseReleaseMsg{X: 1, Y: 0, Button: tea.MouseLeft})
+
+	if id := Captured(); id != "" {
+		t.Errorf("got captured %q, want none after release", id)
+	}
+
+	m := Unwrap(wrapped).(testWrapModel)
+	if len(m.received) != 6 {
+		t.Fatalf("got %d messages, want 6", len(m.received))
+	}
+
+	motion, ok := m.received[3].(MsgZoneMotion)
+	if !ok {
+		t.Fatalf("got %T, want MsgZoneMotion", m.received[3])
+	}
+
+	if motion.X != -3 || motion.Y != -2 {
+		t.Errorf("got relative position %d,%d, want -3,-2", motion.X, motion.Y)
+	}
+
+	if _, ok := m.received[5].(MsgZoneRelease); !ok {
+		t.Errorf("got %T, want MsgZoneRelease", m.received[5])
+	}
+
+	wrapped = Wrap(Unwrap(wrapped), WithAutoCapture(false))
+	_, _ = wrapped.Update(tea.MouseClickMsg{X: 5, Y: 2})
+
+	if id := Captured(); id != "" {
+		t.Errorf("got captured %q, want none with auto capture disabled", id)
+	}
+}
+
+func TestWrapAutoCaptureNested(t *testing.T) {
+	zm := New(WithSyncCommit(true))
+	defer zm.Close()
+
+	var handled []string
+	handler := func(evt ZoneEvent) tea.Cmd {
+		handled = append(handled, evt.ID)
+		return nil
+	}
+
+	_ = zm.Scan(zm.MarkFunc("outer", "ab "+zm.MarkFunc("inner", "cd", handler), handler))
+
+	w := zm.Wrap(testWrapModel{}, WithClickDispatch(DispatchInstead), WithReleaseDispatch(DispatchInstead))
+
+	// The press should reach all zones in bounds, and only then capture the
+	// innermost zone.
+	w, _ = w.Update(tea.MouseClickMsg{X: 3, Y: 0, Button: tea.MouseLeft})
+	if id := zm.Captured(); id != "inner" {
+		t.Fatalf("got captured %q, want %q", id, "inner")
+	}
+	if len(handled) != 2 {
+		t.Errorf("got handlers %v, want both zones", handled)
+	}
+
+	// The release should reach all zones in bounds, including the parent zone,
+	// as well as the captured zone when released outside of it.
+	w, _ = w.Update(tea.MouseReleaseMsg{X: 3, Y: 0, Button: tea.MouseLeft})
+	w, _ = w.Update(tea.MouseClickMsg{X: 3, Y: 0, Button: tea.MouseLeft})
+	w, _ = w.Update(tea.MouseReleaseMsg{X: 0, Y: 0, Button: tea.MouseLeft})
+
+	var ids []string
+	for _, msg := range Unwrap(w).(testWrapModel).received {
+		switch msg := msg.(type) {
+		case MsgZoneClick:
+			ids = append(ids, "click:"+msg.ID)
+		case MsgZoneRelease:
+			ids = append(ids, "release:"+msg.ID)
+		}
+	}
+
+	want := []string{
+		"click:inner", "click:outer", "release:inner", "release:outer",
+		"click:inner", "click:outer", "release:inner", "release:outer",
+	}
+	if strings.Join(ids, ",") != strings.Join(want, ",") {
+		t.Errorf("got %v, want %v", ids, want)
+	}
+
+	if want := "inner,outer,inner,outer,inner,outer,inner,outer"; strings.Join(handled, ",") != want {
+		t.Errorf("got handlers %v, want %v", handled, want)
+	}
+
+	if id := zm.Captured(); id != "" {
+		t.Errorf("got captured %q, want none", id)
+	}
+}
+
+func TestWrapAutoCaptureLostRelease(t *testing.T) {
+	zm := New(WithSyncCommit(true))
+	defer zm.Close()
+
+	_ = zm.Scan(zm.Mark("foo", "ab") + " " + zm.Mark("bar", "cd"))
+	w := zm.Wrap(testWrapModel{})
+
+	// A new press without a release should capture the new zone.
+	w, _ = w.Update(tea.MouseClickMsg{X: 0, Y: 0, Button: tea.MouseLeft})
+	w, _ = w.Update(tea.MouseClickMsg{X: 3, Y: 0, Button: tea.MouseLeft})
+	if id := zm.Captured(); id != "bar" {
+		t.Errorf("got captured %q, want %q after a lost release", id, "bar")
+	}
+
+	// Motion without a button held means the button was released.
+	w, _ = w.Update(tea.MouseMotionMsg{X: 0, Y: 0})
+	if id := zm.Captured(); id != "" {
+		t.Errorf("got captured %q, want none after motion without a button", id)
+	}
+
+	// Manual captures aren't released by new presses, only by losing focus.
+	zm.Capture("foo")
+	w, _ = w.Update(tea.MouseClickMsg{X: 3, Y: 0, Button: tea.MouseLeft})
+	if id := zm.Captured(); id != "foo" {
+		t.Errorf("got captured %q, want %q", id, "foo")
+	}
+
+	_, _ = w.Update(tea.BlurMsg{})
+	if id := zm.Captured(); id != "" {
+		t.Errorf("got captured %q, want none after losing focus", id)
+	}
+}
@@ -0,0 +1,217 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package zone
+
+import (
+	"math"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/rivo/uniseg"
+)
+
+// caretConfig holds the configuration of a caret lookup.
+type caretConfig struct {
+	offset int       // Number of runes scrolled out of view, before the line.
+	prefix int       // Number of cells before the text (e.g. a prompt).
+	limit  int       // Maximum index to return, or -1 for no limit.
+	width  WidthFunc // Width of each grapheme cluster.
+}
+
+// newCaretConfig returns the configuration of a caret lookup, measuring cells
+// with width, unless overridden by opts.
+func newCaretConfig(width WidthFunc, opts []CaretOption) *caretConfig {
+	cfg := &caretConfig{limit: -1, width: width}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+// CaretOption is an option which can be passed to Caret() and related methods.
+type CaretOption func(*caretConfig)
+
+// WithCaretOffset sets the number of runes which have been scrolled out of view
+// before the start of the rendered line, e.g. in a horizontally scrolling text
+// input. The offset is added to the returned index.
+func WithCaretOffset(runes int) CaretOption {
+	return func(cfg *caretConfig) {
+		cfg.offset = max(0, runes)
+	}
+}
+
+// WithCaretPrefix sets the number of cells rendered before the text within the
+// line, e.g. a prompt or line number, which aren't counted. Positions within the
+// prefix are treated as the start of the text.
+func WithCaretPrefix(cells int) CaretOption {
+	return func(cfg *caretConfig) {
+		cfg.prefix = max(0, cells)
+	}
+}
+
+// WithCaretLimit sets the maximum index which can be returned, which is usually
+// the length (in runes) of the value being edited. This ensures padding, a
+// trailing cursor, suggestions or a placeholder (use a limit of 0) rendered after
+// the value aren't treated as part of the value.
+func WithCaretLimit(runes int) CaretOption {
+	return func(cfg *caretConfig) {
+		cfg.limit = max(0, runes)
+	}
+}
+
+// WithCaretWidthFunc sets the function used to measure the width of each
+// grapheme cluster, which should match the width function of the manager (see
+// WithWidthFunc() and Manager.Width()), so positions match the coordinates of
+// zones. Defaults to the default width function of managers. The caret methods
+// of ZoneInfo default to the width function of the manager of the zone.
+func WithCaretWidthFunc(fn WidthFunc) CaretOption {
+	return func(cfg *caretConfig) {
+		if fn != nil {
+			cfg.width = fn
+		}
+	}
+}
+
+// Caret returns the index of the rune under cell x of the rendered line, which may
+// include ANSI sequences and zone markers, and may contain wide characters. The
+// returned index is always the first rune of a grapheme cluster, so combining
+// characters and emoji sequences are treated as a single character. If x is past
+// the end of the line, the number of runes in the text is returned. Cells are
+// measured like the default width function of managers, see
+// WithCaretWidthFunc().
+func Caret(line string, x int, opts ...CaretOption) int {
+	return caret(line, x, newCaretConfig(printableRuneWidth, opts))
+}
+
+// caret is the same as Caret(), using the provided configuration.
+func caret(line string, x int, cfg *caretConfig) int {
+	var index, width int
+	state := -1
+	rest := ansi.Strip(line)
+	x = max(x, cfg.prefix)
+
+	for len(rest) > 0 {
+		var cluster string
+		cluster, rest, _, state = uniseg.FirstGraphemeClusterInString(rest, state)
+
+		w := cfg.width(cluster)
+
+		if width+w > x {
+			break
+		}
+
+		width += w
+		if width > cfg.prefix {
+			index += len([]rune(cluster))
+		}
+	}
+
+	index += cfg.offset
+	if cfg.limit >= 0 {
+		index = min(index, cfg.limit)
+	}
+	return index
+}
+
+// Caret returns the index of the rune under the mouse event, within the provided
+// rendered line of the zone (see the package-level Caret() for details), measured
+// with the width function of the manager of the zone. For zones spanning multiple
+// lines, line should be the line the mouse event is on. If the mouse event isn't
+// within the zone, -1 is returned.
+//
+// Negative positions are treated as the start of the line, and positions past
+// the end of the line as the end, which allows use with captured mouse events
+// (see Manager.Capture()).
+func (z *ZoneInfo) Caret(line string, msg tea.MouseMsg, opts ...CaretOption) int {
+	x, _, ok := z.RelativePos(msg)
+	if !ok {
+		return -1
+	}
+
+	return caret(line, max(0, x), z.caretConfig(opts))
+}
+
+// caretConfig returns the configuration of a caret lookup within the zone,
+// measuring cells with the width function of the manager of the zone.
+func (z *ZoneInfo) caretConfig(opts []CaretOption) *caretConfig {
+	width := WidthFunc(printableRuneWidth)
+	if z.manager != nil {
+		width = z.manager.width
+	}
+	return newCaretConfig(width, opts)
+}
+
+// CaretRange returns the range of runes between the start and end mouse events,
+// e.g. from a mouse press and the current position while dragging, within the
+// provided rendered line of the zone. The returned range is ordered, with start
+// being inclusive, and end exclusive. If either mouse event isn't within the zone
+// (and the zone doesn't have the mouse captured), ok is false.
+func (z *ZoneInfo) CaretRange(line string, start, end tea.MouseMsg, opts ...CaretOption) (from, to int, ok bool) {
+	from, to = z.Caret(line, start, opts...), z.Caret(line, end, opts...)
+	if from == -1 || to == -1 {
+		return 0, 0, false
+	}
+
+	if from > to {
+		from, to = to, from
+	}
+	return from, to, true
+}
+
+// CaretPosition is the position of a caret within multiple lines of text.
+type CaretPosition struct {
+	Line  int // Index of the line.
+	Index int // Index of the rune within the line.
+}
+
+// Before returns true if p is before other.
+func (p CaretPosition) Before(other CaretPosition) bool {
+	return p.Line < other.Line || (p.Line == other.Line && p.Index < other.Index)
+}
+
+// CaretAt returns the line and rune index under the mouse event, within the
+// provided rendered lines of the zone (one per line of the zone, see the
+// package-level Caret() for details). This is useful for zones containing
+// multiple lines of text, which aren't soft wrapped or scrolled.
+//
+// Positions above the first line are treated as the start of the text, and
+// positions below the last line as the end, which allows use with captured mouse
+// events (see Manager.Capture()). If the mouse event isn't within the zone, or
+// no lines are provided, ok is false.
+func (z *ZoneInfo) CaretAt(lines []string, msg tea.MouseMsg, opts ...CaretOption) (pos CaretPosition, ok bool) {
+	x, y, ok := z.RelativePos(msg)
+	if !ok || len(lines) == 0 {
+		return pos, false
+	}
+
+	switch {
+	case y < 0:
+		return CaretPosition{}, true
+	case y >= len(lines):
+		y, x = len(lines)-1, math.MaxInt
+	}
+
+	return CaretPosition{Line: y, Index: caret(lines[y], max(0, x), z.caretConfig(opts))}, true
+}
+
+// CaretSpan is the same as CaretRange(), however for zones containing multiple
+// lines of text (see CaretAt()). The returned positions are ordered, with from
+// being inclusive, and to exclusive.
+func (z *ZoneInfo) CaretSpan(lines []string, start, end tea.MouseMsg, opts ...CaretOption) (from, to CaretPosition, ok bool) {
+	from, ok = z.CaretAt(lines, start, opts...)
+	if !ok {
+		return from, to, false
+	}
+
+	to, ok = z.CaretAt(lines, end, opts...)
+	if !ok {
+		return from, to, false
+	}
+
+	if to.Before(from) {
+		from, to = to, from
+	}
+	return from, to, true
+}
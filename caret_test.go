@@ -0,0 +1,140 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package zone
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestCaret(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		x    int
+		opts []CaretOption
+		want int
+	}{
+		{"start", "hello", 0, nil, 0},
+		{"middle", "hello", 2, nil, 2},
+		{"past-end", "hello", 10, nil, 5},
+		{"negative", "hello", -3, nil, 0},
+		{"wide-first-cell", "a世界", 1, nil, 1},
+		{"wide-second-cell", "a世界", 2, nil, 1},
+		{"after-wide", "a世界", 3, nil, 2},
+		{"combining", "éx", 1, nil, 2},
+		{"ansi", "\x1b[31mred\x1b[0m blue", 4, nil, 4},
+		{"markers", "\x1b[1000zab\x1b[1000zcd", 3, nil, 3},
+		{"prefix", "> hello", 3, []CaretOption{WithCaretPrefix(2)}, 1},
+		{"within-prefix", "> hello", 0, []CaretOption{WithCaretPrefix(2)}, 0},
+		{"offset", "llo", 1, []CaretOption{WithCaretOffset(2)}, 3},
+		{"limit", "hi   ", 4, []CaretOption{WithCaretLimit(2)}, 2},
+		{"placeholder", "type here", 4, []CaretOption{WithCaretLimit(0)}, 0},
+		{"width-func", "abcd", 5, []CaretOption{WithCaretWidthFunc(doubleWidth)}, 2},
+	}
+
+	for _, test := range tests {
+		if got := Caret(test.line, test.x, test.opts...); got != test.want {
+			t.Errorf("%s: got %d, want %d", test.name, got, test.want)
+		}
+	}
+}
+
+// doubleWidth is a width function where every rune is two cells wide.
+func doubleWidth(s string) int {
+	return 2 * utf8.RuneCountInString(ansi.Strip(s))
+}
+
+func TestZoneCaretWidthFunc(t *testing.T) {
+	zm := New(WithSyncCommit(true), WithWidthFunc(doubleWidth))
+	defer zm.Close()
+
+	_ = zm.Scan("ab" + zm.Mark("input", "cdef"))
+	z := zm.Get("input")
+
+	// The zone starts at cell 4, and each rune is two cells wide.
+	if got := z.Caret("cdef", tea.MouseClickMsg{X: 7}); got != 1 {
+		t.Errorf("got %d, want 1", got)
+	}
+
+	if pos, ok := z.CaretAt([]string{"cdef"}, tea.MouseClickMsg{X: 8}); !ok || pos.Index != 2 {
+		t.Errorf("got %+v (%v), want index 2", pos, ok)
+	}
+}
+
+func TestZoneCaretRange(t *testing.T) {
+	zm := New()
+	defer zm.Close()
+
+	line := "世界 hello"
+	_ = zm.Scan("ab" + zm.Mark("input", line))
+	time.Sleep(100 * time.Millisecond)
+
+	z := zm.Get("input")
+
+	if got := z.Caret(line, tea.MouseClickMsg{X: 5}); got != 1 {
+		t.Errorf("got %d, want 1", got)
+	}
+
+	if got := z.Caret(line, tea.MouseClickMsg{X: 0}); got != -1 {
+		t.Errorf("got %d, want -1 outside of the zone", got)
+	}
+
+	from, to, ok := z.CaretRange(line, tea.MouseClickMsg{X: 9}, tea.MouseMotionMsg{X: 3})
+	if !ok || from != 0 || to != 5 {
+		t.Errorf("got (%d, %d, %v), want (0, 5, true)", from, to, ok)
+	}
+
+	if _, _, ok = z.CaretRange(line, tea.MouseClickMsg{X: 9}, tea.MouseMotionMsg{X: 20}); ok {
+		t.Error("expected range outside of the zone to fail")
+	}
+
+	// While captured, positions outside of the zone are clamped.
+	zm.Capture("input")
+	defer zm.Release()
+
+	from, to, ok = z.CaretRange(line, tea.MouseClickMsg{X: 9}, tea.MouseMotionMsg{X: 20})
+	if !ok || from != 5 || to != 8 {
+		t.Errorf("got (%d, %d, %v), want (5, 8, true)", from, to, ok)
+	}
+}
+
+func TestZoneCaretSpan(t *testing.T) {
+	zm := New(WithSyncCommit(true))
+	defer zm.Close()
+
+	lines := []string{"foo bar", "世界 baz"}
+	_ = zm.Scan("ab" + zm.Mark("text", strings.Join(lines, "\n")))
+
+	z := zm.Get("text")
+
+	if pos, ok := z.CaretAt(lines, tea.MouseClickMsg{X: 4, Y: 1}); !ok || pos != (CaretPosition{Line: 1, Index: 1}) {
+		t.Errorf("got %+v (%v), want line 1 index 1", pos, ok)
+	}
+
+	from, to, ok := z.CaretSpan(lines, tea.MouseClickMsg{X: 5, Y: 1}, tea.MouseMotionMsg{X: 6, Y: 0})
+	if !ok || from != (CaretPosition{Line: 0, Index: 4}) || to != (CaretPosition{Line: 1, Index: 1}) {
+		t.Errorf("got %+v to %+v (%v), want line 0 index 4 to line 1 index 1", from, to, ok)
+	}
+
+	if _, _, ok = z.CaretSpan(lines, tea.MouseClickMsg{X: 5, Y: 1}, tea.MouseMotionMsg{X: 5, Y: 5}); ok {
+		t.Error("expected span outside of the zone to fail")
+	}
+
+	// While captured, positions above and below the zone are clamped to the
+	// start and end of the text.
+	zm.Capture("text")
+	defer zm.Release()
+
+	from, to, ok = z.CaretSpan(lines, tea.MouseMotionMsg{X: 20, Y: 5}, tea.MouseMotionMsg{X: 0, Y: -1})
+	if !ok || from != (CaretPosition{}) || to != (CaretPosition{Line: 1, Index: 6}) {
+		t.Errorf("got %+v to %+v (%v), want the whole text", from, to, ok)
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package components
+
+import (
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	zone "github.com/lrstanley/bubblezone/v2"
+)
+
+// ButtonPressedMsg is sent when a button is clicked, or activated with the
+// keyboard while focused.
+type ButtonPressedMsg struct {
+	ID string // ID of the button, see Button.ID().
+}
+
+// ButtonStyles are the styles used to render a button.
+type ButtonStyles struct {
+	Normal  lipgloss.Style
+	Focused lipgloss.Style
+}
+
+// DefaultButtonStyles returns the default styles of a button.
+func DefaultButtonStyles() ButtonStyles {
+	normal := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#FFF7DB")).
+		Background(lipgloss.Color("#888B7E")).
+		Padding(0, 3)
+
+	return ButtonStyles{
+		Normal:  normal,
+		Focused: normal.Background(lipgloss.Color("#F25D94")).Underline(true),
+	}
+}
+
+// Button is a clickable button, which can also be activated with the enter or
+// space keys while focused.
+type Button struct {
+	manager *zone.Manager
+	id      string
+	focused bool
+
+	Label  string
+	Styles ButtonStyles
+}
+
+// NewButton returns a new button with the provided label.
+func NewButton(manager *zone.Manager, label string) Button {
+	return Button{
+		manager: manager,
+		id:      manager.NewPrefix(),
+		Label:   label,
+		Styles:  DefaultButtonStyles(),
+	}
+}
+
+// ID returns the unique ID of the button, which is also used as its zone ID.
+func (b Button) ID() string {
+	return b.id
+}
+
+// Focus focuses the button, allowing it to be activated with the keyboard.
+func (b *Button) Focus() {
+	b.focused = true
+}
+
+// Blur removes focus from the button.
+func (b *Button) Blur() {
+	b.focused = false
+}
+
+// Focused returns true if the button is focused.
+func (b Button) Focused() bool {
+	return b.focused
+}
+
+// Update handles mouse and keyboard events, returning a command which sends a
+// ButtonPressedMsg when the button is pressed.
+func (b Button) Update(msg tea.Msg) (Button, tea.Cmd) {
+	if clicked(b.manager, b.id, msg) || activated(b.focused, msg) {
+		return b, cmd(ButtonPressedMsg{ID: b.id})
+	}
+	return b, nil
+}
+
+// View renders the button.
+func (b Button) View() string {
+	style := b.Styles.Normal
+	if b.focused {
+		style = b.Styles.Focused
+	}
+	return b.manager.Mark(b.id, style.Render(b.Label))
+}
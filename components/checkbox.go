@@ -0,0 +1,103 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package components
+
+import (
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	zone "github.com/lrstanley/bubblezone/v2"
+)
+
+// CheckboxChangedMsg is sent when a checkbox is checked or unchecked.
+type CheckboxChangedMsg struct {
+	ID      string // ID of the checkbox, see Checkbox.ID().
+	Checked bool   // The new state of the checkbox.
+}
+
+// CheckboxStyles are the styles used to render a checkbox.
+type CheckboxStyles struct {
+	Normal  lipgloss.Style
+	Focused lipgloss.Style
+
+	Checked   string // Box shown when checked.
+	Unchecked string // Box shown when unchecked.
+}
+
+// DefaultCheckboxStyles returns the default styles of a checkbox.
+func DefaultCheckboxStyles() CheckboxStyles {
+	return CheckboxStyles{
+		Normal:    lipgloss.NewStyle(),
+		Focused:   lipgloss.NewStyle().Foreground(lipgloss.Color("#7D56F4")),
+		Checked:   "[x]",
+		Unchecked: "[ ]",
+	}
+}
+
+// Checkbox is a clickable checkbox, which can also be toggled with the enter or
+// space keys while focused.
+type Checkbox struct {
+	manager *zone.Manager
+	id      string
+	focused bool
+
+	Label   string
+	Checked bool
+	Styles  CheckboxStyles
+}
+
+// NewCheckbox returns a new unchecked checkbox with the provided label.
+func NewCheckbox(manager *zone.Manager, label string) Checkbox {
+	return Checkbox{
+		manager: manager,
+		id:      manager.NewPrefix(),
+		Label:   label,
+		Styles:  DefaultCheckboxStyles(),
+	}
+}
+
+// ID returns the unique ID of the checkbox, which is also used as its zone ID.
+func (c Checkbox) ID() string {
+	return c.id
+}
+
+// Focus focuses the checkbox, allowing it to be toggled with the keyboard.
+func (c *Checkbox) Focus() {
+	c.focused = true
+}
+
+// Blur removes focus from the checkbox.
+func (c *Checkbox) Blur() {
+	c.focused = false
+}
+
+// Focused returns true if the checkbox is focused.
+func (c Checkbox) Focused() bool {
+	return c.focused
+}
+
+// Update handles mouse and keyboard events, toggling the checkbox and returning
+// a command which sends a CheckboxChangedMsg when it is toggled.
+func (c Checkbox) Update(msg tea.Msg) (Checkbox, tea.Cmd) {
+	if clicked(c.manager, c.id, msg) || activated(c.focused, msg) {
+		c.Checked = !c.Checked
+		return c, cmd(CheckboxChangedMsg{ID: c.id, Checked: c.Checked})
+	}
+	return c, nil
+}
+
+// View renders the checkbox.
+func (c Checkbox) View() string {
+	style := c.Styles.Normal
+	if c.focused {
+		style = c.Styles.Focused
+	}
+
+	box := c.Styles.Unchecked
+	if c.Checked {
+		box = c.Styles.Checked
+	}
+
+	return c.manager.Mark(c.id, style.Render(box+" "+c.Label))
+}
@@ -0,0 +1,48 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+// Package components provides common clickable components (buttons, checkboxes,
+// toggles, radio groups, tab bars, tables and split panes), which use zones for
+// mouse interaction, and also support keyboard activation when focused.
+//
+// All components require a *zone.Manager, and don't depend on the global
+// zone.DefaultManager. The root model must still scan the view, e.g. using
+// zone.Scan() or zone.Wrap().
+package components
+
+import (
+	tea "charm.land/bubbletea/v2"
+	zone "github.com/lrstanley/bubblezone/v2"
+)
+
+// clicked returns true if msg is a left mouse button release within the zone
+// with the provided ID.
+func clicked(manager *zone.Manager, id string, msg tea.Msg) bool {
+	release, ok := msg.(tea.MouseReleaseMsg)
+	if !ok || release.Button != tea.MouseLeft {
+		return false
+	}
+	return manager.Get(id).InBounds(release)
+}
+
+// activated returns true if msg is a key press which should activate a focused
+// component.
+func activated(focused bool, msg tea.Msg) bool {
+	key, ok := msg.(tea.KeyPressMsg)
+	if !ok || !focused {
+		return false
+	}
+
+	switch key.String() {
+	case "enter", "space":
+		return true
+	default:
+		return false
+	}
+}
+
+// cmd returns a command which sends msg.
+func cmd(msg tea.Msg) tea.Cmd {
+	return func() tea.Msg { return msg }
+}
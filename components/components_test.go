@@ -0,0 +1,311 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package components
+
+import (
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	zone "github.com/lrstanley/bubblezone/v2"
+)
+
+func scan(t *testing.T, manager *zone.Manager, view string) string {
+	t.Helper()
+	out := manager.Scan(view)
+	time.Sleep(50 * time.Millisecond)
+	return out
+}
+
+func click(x, y int) tea.MouseReleaseMsg {
+	return tea.MouseReleaseMsg{X: x, Y: y, Button: tea.MouseLeft}
+}
+
+func msgOf(t *testing.T, cmd tea.Cmd) tea.Msg {
+	t.Helper()
+	if cmd == nil {
+		t.Fatal("expected command")
+	}
+	return cmd()
+}
+
+func TestButton(t *testing.T) {
+	zm := zone.New()
+	defer zm.Close()
+
+	b := NewButton(zm, "OK")
+	b.Styles = ButtonStyles{Normal: lipgloss.NewStyle(), Focused: lipgloss.NewStyle()}
+
+	if got := scan(t, zm, "aa "+b.View()); got != "aa OK" {
+		t.Fatalf("got %q, want %q", got, "aa OK")
+	}
+
+	if _, cmd := b.Update(click(0, 0)); cmd != nil {
+		t.Error("expected no command when clicking outside of the button")
+	}
+
+	_, cmd := b.Update(click(4, 0))
+	if msg, ok := msgOf(t, cmd).(ButtonPressedMsg); !ok || msg.ID != b.ID() {
+		t.Errorf("got %#v, want ButtonPressedMsg", msg)
+	}
+
+	if _, cmd = b.Update(tea.KeyPressMsg{Code: tea.KeyEnter}); cmd != nil {
+		t.Error("expected no command when not focused")
+	}
+
+	b.Focus()
+	if _, cmd = b.Update(tea.KeyPressMsg{Code: tea.KeySpace}); cmd == nil {
+		t.Error("expected command when focused")
+	}
+}
+
+func TestCheckbox(t *testing.T) {
+	zm := zone.New()
+	defer zm.Close()
+
+	c := NewCheckbox(zm, "Milk")
+	if got := scan(t, zm, c.View()); got != "[ ] Milk" {
+		t.Fatalf("got %q, want %q", got, "[ ] Milk")
+	}
+
+	c, cmd := c.Update(click(5, 0))
+	if msg, ok := msgOf(t, cmd).(CheckboxChangedMsg); !ok || !msg.Checked || !c.Checked {
+		t.Errorf("got %#v, want checked", msg)
+	}
+
+	c.Focus()
+	c, _ = c.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if c.Checked {
+		t.Error("expected unchecked after keyboard activation")
+	}
+}
+
+func TestToggle(t *testing.T) {
+	zm := zone.New()
+	defer zm.Close()
+
+	tg := NewToggle(zm, "Dark mode")
+	_ = scan(t, zm, tg.View())
+
+	tg, cmd := tg.Update(click(0, 0))
+	if msg, ok := msgOf(t, cmd).(ToggleChangedMsg); !ok || !msg.On || !tg.On {
+		t.Errorf("got %#v, want on", msg)
+	}
+}
+
+func TestRadioGroup(t *testing.T) {
+	zm := zone.New()
+	defer zm.Close()
+
+	r := NewRadioGroup(zm, "Small", "Medium", "Large")
+	r.Styles.Option = lipgloss.NewStyle()
+	r.Styles.Selected = lipgloss.NewStyle()
+	_ = scan(t, zm, r.View())
+
+	r, cmd := r.Update(click(1, 2))
+	if msg, ok := msgOf(t, cmd).(RadioChangedMsg); !ok || msg.Index != 2 || msg.Option != "Large" {
+		t.Errorf("got %#v, want Large", msg)
+	}
+
+	if _, cmd = r.Update(click(1, 2)); cmd != nil {
+		t.Error("expected no command when clicking the selected option")
+	}
+
+	r.Focus()
+	r, _ = r.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	if r.Value() != "Small" {
+		t.Errorf("got %q, want wrap around to %q", r.Value(), "Small")
+	}
+}
+
+func TestTabs(t *testing.T) {
+	zm := zone.New()
+	defer zm.Close()
+
+	tabs := NewTabs(zm, "One", "Two", "Three")
+	plain := lipgloss.NewStyle().MarginRight(1)
+	tabs.Styles = TabStyles{Tab: plain, Active: plain, Focused: plain, Gap: lipgloss.NewStyle()}
+
+	if got := scan(t, zm, tabs.View()); got != "One Two Three " {
+		t.Fatalf("got %q, want %q", got, "One Two Three ")
+	}
+
+	tabs, cmd := tabs.Update(click(5, 0))
+	if msg, ok := msgOf(t, cmd).(TabChangedMsg); !ok || msg.Index != 1 || msg.Tab != "Two" {
+		t.Errorf("got %#v, want Two", msg)
+	}
+
+	tabs.Focus()
+	tabs, _ = tabs.Update(tea.KeyPressMsg{Code: tea.KeyRight})
+	if tabs.Value() != "Three" {
+		t.Errorf("got %q, want %q", tabs.Value(), "Three")
+	}
+}
+
+func TestTable(t *testing.T) {
+	zm := zone.New()
+	defer zm.Close()
+
+	table := NewTable(zm, []TableColumn{{Title: "Name", Width: 6}, {Title: "Qty", Width: 4}}, [][]string{
+		{"pear", "3"},
+		{"apple", "10"},
+		{"banana", "7"},
+	})
+	table.Styles.Header = lipgloss.NewStyle()
+	table.Styles.Selected = lipgloss.NewStyle()
+
+	want := "Name  │Qty │\npear  │3   │\napple │10  │\nbanana│7   │"
+	if got := scan(t, zm, table.View()); got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+
+	if z := zm.Get(table.CellID(1, 1)); z.StartX != 7 || z.StartY != 2 || z.EndX != 10 {
+		t.Errorf("got cell zone (%d, %d)-(%d, %d), want (7, 2)-(10, 2)", z.StartX, z.StartY, z.EndX, z.EndY)
+	}
+	if z := zm.Get(table.HeaderID(1)); z.StartX != 7 || z.StartY != 0 {
+		t.Errorf("got header zone at (%d, %d), want (7, 0)", z.StartX, z.StartY)
+	}
+
+	tests := []struct {
+		name string
+		x, y int
+		want TableHit
+		ok   bool
+	}{
+		{"header", 1, 0, TableHit{Row: -1, Col: 0, IsHeader: true}, true},
+		{"resize", 6, 0, TableHit{Row: -1, Col: 0, IsHeader: true, IsResizeHandle: true}, true},
+		{"cell", 8, 2, TableHit{Row: 1, Col: 1}, true},
+		{"last-cell", 9, 3, TableHit{Row: 2, Col: 1}, true},
+		{"separator", 6, 2, TableHit{}, false},
+		{"outside", 20, 2, TableHit{}, false},
+		{"below", 1, 4, TableHit{}, false},
+	}
+
+	for _, test := range tests {
+		hit, ok := table.Hit(tea.MouseMotionMsg{X: test.x, Y: test.y})
+		if ok != test.ok || hit != test.want {
+			t.Errorf("%s: got %#v (%v), want %#v (%v)", test.name, hit, ok, test.want, test.ok)
+		}
+	}
+
+	// Clicking a cell selects the row.
+	table, cmd := table.Update(click(1, 3))
+	if msg, ok := msgOf(t, cmd).(TableCellClickedMsg); !ok || msg.Hit.Row != 2 || table.Cursor != 2 {
+		t.Errorf("got %#v, want row 2 selected", msg)
+	}
+
+	// Clicking a header sorts by that column, toggling the direction, with the
+	// cursor following the selected row.
+	table, _ = table.Update(click(1, 0))
+	if table.SortCol != 0 || table.Descending || table.Rows[0][0] != "apple" {
+		t.Errorf("got sort %d (desc %v), first row %q, want ascending by name", table.SortCol, table.Descending, table.Rows[0][0])
+	}
+	if table.Rows[table.Cursor][0] != "banana" {
+		t.Errorf("got selected row %q, want %q", table.Rows[table.Cursor][0], "banana")
+	}
+
+	_ = scan(t, zm, table.View())
+	table, _ = table.Update(click(1, 0))
+	if !table.Descending || table.Rows[0][0] != "pear" {
+		t.Errorf("got desc %v, first row %q, want descending by name", table.Descending, table.Rows[0][0])
+	}
+
+	table.SortBy(1, false)
+	if table.Rows[0][1] != "10" || table.Rows[table.Cursor][0] != "banana" {
+		t.Errorf("got first row %v, selected row %v, want sorted by quantity with banana selected", table.Rows[0], table.Rows[table.Cursor])
+	}
+
+	// Dragging a resize handle resizes the column, even outside of the handle.
+	_ = scan(t, zm, table.View())
+	table, _ = table.Update(tea.MouseClickMsg{X: 6, Y: 0, Button: tea.MouseLeft})
+	if zm.Captured() != table.ResizeID(0) {
+		t.Errorf("got captured %q, want resize handle", zm.Captured())
+	}
+
+	table, _ = table.Update(tea.MouseMotionMsg{X: 10, Y: 4, Button: tea.MouseLeft})
+	table, cmd = table.Update(tea.MouseReleaseMsg{X: 10, Y: 4, Button: tea.MouseLeft})
+
+	if msg, ok := msgOf(t, cmd).(TableColumnResizedMsg); !ok || msg.Col != 0 || msg.Width != 10 || table.Columns[0].Width != 10 {
+		t.Errorf("got %#v, want column 0 resized to 10", msg)
+	}
+
+	if zm.Captured() != "" {
+		t.Error("expected capture to be released")
+	}
+
+	_ = scan(t, zm, table.View())
+	table, _ = table.Update(tea.MouseClickMsg{X: 10, Y: 0, Button: tea.MouseLeft})
+	table, _ = table.Update(tea.MouseMotionMsg{X: 0, Y: 0, Button: tea.MouseLeft})
+	if table.Columns[0].Width != table.Styles.MinColWidth {
+		t.Errorf("got width %d, want minimum width %d", table.Columns[0].Width, table.Styles.MinColWidth)
+	}
+}
+
+func TestSplit(t *testing.T) {
+	zm := zone.New()
+	defer zm.Close()
+
+	split := NewSplit(zm, SplitHorizontal, 3)
+	split.Styles = SplitStyles{HorizontalChar: "|", VerticalChar: "-"}
+	split.Max = 6
+	split.SetSize(10, 2)
+
+	if got, want := scan(t, zm, split.View("aaaaa", "b\nb")), "aaa|b     \n   |b     "; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+
+	divider := zm.Get(split.DividerID())
+	if divider.StartX != 3 || divider.EndX != 3 || divider.StartY != 0 || divider.EndY != 1 {
+		t.Fatalf("got divider %#v, want at x=3 spanning both lines", divider)
+	}
+
+	split, _ = split.Update(tea.MouseClickMsg{X: 3, Y: 1, Button: tea.MouseLeft})
+	if !split.Dragging() || zm.Captured() != split.DividerID() {
+		t.Fatal("expected divider to be dragged, and capture the mouse")
+	}
+
+	split, c := split.Update(tea.MouseMotionMsg{X: 5, Y: 4})
+	if msg, ok := msgOf(t, c).(SplitResizedMsg); !ok || msg.Size != 5 || !msg.Dragging {
+		t.Errorf("got %#v, want SplitResizedMsg with size 5", msg)
+	}
+
+	// Clamped to the maximum, and to the minimum of either pane.
+	if split, _ = split.Update(tea.MouseMotionMsg{X: 20, Y: 0}); split.Size != 6 {
+		t.Errorf("got size %d, want 6", split.Size)
+	}
+	if split, _ = split.Update(tea.MouseMotionMsg{X: -5, Y: 0}); split.Size != 1 {
+		t.Errorf("got size %d, want 1", split.Size)
+	}
+
+	split, c = split.Update(tea.MouseReleaseMsg{X: -5, Y: 0, Button: tea.MouseLeft})
+	if msg, ok := msgOf(t, c).(SplitResizedMsg); !ok || msg.Size != 1 || msg.Dragging {
+		t.Errorf("got %#v, want final SplitResizedMsg", msg)
+	}
+	if split.Dragging() || zm.Captured() != "" {
+		t.Error("expected drag to end, and release the mouse")
+	}
+
+	split.Focus()
+	if split, _ = split.Update(tea.KeyPressMsg{Code: tea.KeyRight}); split.Size != 2 {
+		t.Errorf("got size %d, want 2", split.Size)
+	}
+
+	// Nested vertical split, within the second pane.
+	split.SetSize(10, 3)
+	nested := NewSplit(zm, SplitVertical, 1)
+	nested.Styles = split.Styles
+	nested.SetSize(split.SecondSize())
+
+	want := "aa|c      \n  |-------\n  |d      "
+	if got := scan(t, zm, split.View("aa", nested.View("c", "d\ne"))); got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+
+	nested, _ = nested.Update(tea.MouseClickMsg{X: 5, Y: 1, Button: tea.MouseLeft})
+	if nested, _ = nested.Update(tea.MouseMotionMsg{X: 5, Y: 3}); nested.Size != 1 {
+		t.Errorf("got size %d, want 1 (clamped to fit)", nested.Size)
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package components
+
+import (
+	"strconv"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	zone "github.com/lrstanley/bubblezone/v2"
+)
+
+// RadioChangedMsg is sent when the selected option of a radio group changes.
+type RadioChangedMsg struct {
+	ID     string // ID of the radio group, see RadioGroup.ID().
+	Index  int    // Index of the newly selected option.
+	Option string // The newly selected option.
+}
+
+// RadioStyles are the styles used to render a radio group.
+type RadioStyles struct {
+	Option   lipgloss.Style // Style of each option.
+	Selected lipgloss.Style // Style of the selected option.
+	Focused  lipgloss.Style // Style of the selected option, when focused.
+
+	SelectedMark   string // Mark shown before the selected option.
+	UnselectedMark string // Mark shown before all other options.
+}
+
+// DefaultRadioStyles returns the default styles of a radio group.
+func DefaultRadioStyles() RadioStyles {
+	return RadioStyles{
+		Option:         lipgloss.NewStyle().MarginRight(2),
+		Selected:       lipgloss.NewStyle().MarginRight(2).Bold(true),
+		Focused:        lipgloss.NewStyle().MarginRight(2).Bold(true).Foreground(lipgloss.Color("#7D56F4")),
+		SelectedMark:   "(•)",
+		UnselectedMark: "( )",
+	}
+}
+
+// RadioGroup is a group of options, where only one option can be selected at a
+// time. Options can be selected by clicking them, or with the arrow keys while
+// focused.
+type RadioGroup struct {
+	manager *zone.Manager
+	id      string
+	focused bool
+
+	Options    []string
+	Selected   int
+	Horizontal bool // Render options next to each other, rather than one per line.
+	Styles     RadioStyles
+}
+
+// NewRadioGroup returns a new radio group with the provided options, with the
+// first option selected.
+func NewRadioGroup(manager *zone.Manager, options ...string) RadioGroup {
+	return RadioGroup{
+		manager: manager,
+		id:      manager.NewPrefix(),
+		Options: options,
+		Styles:  DefaultRadioStyles(),
+	}
+}
+
+// ID returns the unique ID of the radio group, which is used as the prefix of
+// the zone ID of each option.
+func (r RadioGroup) ID() string {
+	return r.id
+}
+
+// Focus focuses the radio group, allowing options to be selected with the
+// keyboard.
+func (r *RadioGroup) Focus() {
+	r.focused = true
+}
+
+// Blur removes focus from the radio group.
+func (r *RadioGroup) Blur() {
+	r.focused = false
+}
+
+// Focused returns true if the radio group is focused.
+func (r RadioGroup) Focused() bool {
+	return r.focused
+}
+
+// Value returns the selected option, or an empty string if there are no
+// options.
+func (r RadioGroup) Value() string {
+	if r.Selected < 0 || r.Selected >= len(r.Options) {
+		return ""
+	}
+	return r.Options[r.Selected]
+}
+
+// optionID returns the zone ID of the option at index i.
+func (r RadioGroup) optionID(i int) string {
+	return r.id + strconv.Itoa(i)
+}
+
+// Update handles mouse and keyboard events, returning a command which sends a
+// RadioChangedMsg when the selected option changes.
+func (r RadioGroup) Update(msg tea.Msg) (RadioGroup, tea.Cmd) {
+	if len(r.Options) == 0 {
+		return r, nil
+	}
+
+	selected := r.Selected
+
+	switch msg := msg.(type) {
+	case tea.MouseReleaseMsg:
+		for i := range r.Options {
+			if clicked(r.manager, r.optionID(i), msg) {
+				selected = i
+				break
+			}
+		}
+	case tea.KeyPressMsg:
+		if !r.focused {
+			return r, nil
+		}
+
+		switch msg.String() {
+		case "up", "left":
+			selected = (selected - 1 + len(r.Options)) % len(r.Options)
+		case "down", "right":
+			selected = (selected + 1) % len(r.Options)
+		}
+	}
+
+	if selected == r.Selected {
+		return r, nil
+	}
+
+	r.Selected = selected
+	return r, cmd(RadioChangedMsg{ID: r.id, Index: r.Selected, Option: r.Value()})
+}
+
+// View renders the radio group.
+func (r RadioGroup) View() string {
+	options := make([]string, len(r.Options))
+
+	for i, option := range r.Options {
+		style, mark := r.Styles.Option, r.Styles.UnselectedMark
+
+		if i == r.Selected {
+			style, mark = r.Styles.Selected, r.Styles.SelectedMark
+			if r.focused {
+				style = r.Styles.Focused
+			}
+		}
+
+		options[i] = r.manager.Mark(r.optionID(i), style.Render(mark+" "+option))
+	}
+
+	if r.Horizontal {
+		return lipgloss.JoinHorizontal(lipgloss.Top, options...)
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, options...)
+}
@@ -0,0 +1,286 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package components
+
+import (
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+	zone "github.com/lrstanley/bubblezone/v2"
+)
+
+// SplitOrientation is the orientation of a split pane.
+type SplitOrientation int
+
+const (
+	// SplitHorizontal places the panes side by side, with a vertical divider.
+	SplitHorizontal SplitOrientation = iota
+
+	// SplitVertical places the panes on top of each other, with a horizontal
+	// divider.
+	SplitVertical
+)
+
+// SplitResizedMsg is sent when the size of the first pane of a split changes,
+// from dragging the divider, or with the keyboard while focused.
+type SplitResizedMsg struct {
+	ID       string // ID of the split, see Split.ID().
+	Size     int    // The new size of the first pane.
+	Dragging bool   // The divider is still being dragged.
+}
+
+// SplitStyles are the styles used to render a split pane.
+type SplitStyles struct {
+	Divider         lipgloss.Style
+	FocusedDivider  lipgloss.Style
+	DraggingDivider lipgloss.Style
+	HorizontalChar  string // Character of the divider of horizontal splits.
+	VerticalChar    string // Character of the divider of vertical splits.
+}
+
+// DefaultSplitStyles returns the default styles of a split pane.
+func DefaultSplitStyles() SplitStyles {
+	divider := lipgloss.NewStyle().Foreground(lipgloss.Color("#383838"))
+
+	return SplitStyles{
+		Divider:         divider,
+		FocusedDivider:  divider.Foreground(lipgloss.Color("#F25D94")),
+		DraggingDivider: divider.Foreground(lipgloss.Color("#7D56F4")),
+		HorizontalChar:  "│",
+		VerticalChar:    "─",
+	}
+}
+
+// splitDrag holds the state of a divider being dragged.
+type splitDrag struct {
+	origin int // Coordinate of the start of the first pane.
+	offset int // Offset of the mouse press within the divider.
+}
+
+// Split is a split pane, with two panes separated by a divider which can be
+// dragged to resize the panes, or moved with the arrow keys while focused. The
+// divider captures the mouse while dragged, so the drag continues even if the
+// mouse moves faster than the view is rendered.
+//
+// Splits can be nested, by rendering a split as a pane of another split, using
+// the size of the pane (see FirstSize() and SecondSize()) as its size.
+type Split struct {
+	manager  *zone.Manager
+	id       string
+	focused  bool
+	dragging *splitDrag
+
+	Orientation SplitOrientation
+	Width       int // Total width of the split, including the divider.
+	Height      int // Total height of the split, including the divider.
+	Size        int // Size of the first pane (width if horizontal, height if vertical).
+	Min         int // Minimum size of either pane.
+	Max         int // Maximum size of the first pane, or 0 for no maximum.
+	Styles      SplitStyles
+}
+
+// NewSplit returns a new split pane, with the first pane of the provided size.
+func NewSplit(manager *zone.Manager, orientation SplitOrientation, size int) Split {
+	return Split{
+		manager:     manager,
+		id:          manager.NewPrefix(),
+		Orientation: orientation,
+		Size:        size,
+		Min:         1,
+		Styles:      DefaultSplitStyles(),
+	}
+}
+
+// ID returns the unique ID of the split, which is used as the prefix of the zone
+// ID of the divider.
+func (s Split) ID() string {
+	return s.id
+}
+
+// DividerID returns the zone ID of the divider.
+func (s Split) DividerID() string {
+	return s.id + "d"
+}
+
+// Focus focuses the split, allowing the divider to be moved with the keyboard.
+func (s *Split) Focus() {
+	s.focused = true
+}
+
+// Blur removes focus from the split.
+func (s *Split) Blur() {
+	s.focused = false
+}
+
+// Focused returns true if the split is focused.
+func (s Split) Focused() bool {
+	return s.focused
+}
+
+// Dragging returns true if the divider is being dragged.
+func (s Split) Dragging() bool {
+	return s.dragging != nil
+}
+
+// SetSize sets the total size of the split, including the divider, clamping the
+// size of the first pane to fit.
+func (s *Split) SetSize(width, height int) {
+	s.Width, s.Height = width, height
+	s.Size = s.clamp(s.Size)
+}
+
+// total returns the size of the split along the split axis.
+func (s Split) total() int {
+	if s.Orientation == SplitVertical {
+		return s.Height
+	}
+	return s.Width
+}
+
+// clamp clamps size to the min/max constraints, and to fit the split.
+func (s Split) clamp(size int) int {
+	if s.Max > 0 {
+		size = min(size, s.Max)
+	}
+	size = min(size, s.total()-1-s.Min)
+	return max(size, s.Min, 0)
+}
+
+// FirstSize returns the width and height of the first pane.
+func (s Split) FirstSize() (width, height int) {
+	if s.Orientation == SplitVertical {
+		return s.Width, s.Size
+	}
+	return s.Size, s.Height
+}
+
+// SecondSize returns the width and height of the second pane.
+func (s Split) SecondSize() (width, height int) {
+	rest := max(0, s.total()-s.Size-1)
+	if s.Orientation == SplitVertical {
+		return s.Width, rest
+	}
+	return rest, s.Height
+}
+
+// Update handles dragging the divider with the mouse, and moving it with the
+// arrow keys while focused, returning a command which sends a SplitResizedMsg
+// when the size changes.
+func (s Split) Update(msg tea.Msg) (Split, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.MouseClickMsg:
+		divider := s.manager.Get(s.DividerID())
+		if msg.Button != tea.MouseLeft || !divider.InBounds(msg) {
+			return s, nil
+		}
+
+		x, y := divider.Pos(msg)
+		s.dragging = &splitDrag{origin: divider.StartX - s.Size, offset: x}
+		if s.Orientation == SplitVertical {
+			s.dragging = &splitDrag{origin: divider.StartY - s.Size, offset: y}
+		}
+
+		s.manager.Capture(s.DividerID())
+	case tea.MouseMotionMsg:
+		if s.dragging == nil {
+			return s, nil
+		}
+
+		pos := msg.X
+		if s.Orientation == SplitVertical {
+			pos = msg.Y
+		}
+		return s.resize(pos-s.dragging.origin-s.dragging.offset, true)
+	case tea.MouseReleaseMsg:
+		if s.dragging == nil || msg.Button != tea.MouseLeft {
+			return s, nil
+		}
+
+		s.dragging = nil
+		s.manager.Release()
+		return s, cmd(SplitResizedMsg{ID: s.id, Size: s.Size})
+	case tea.KeyPressMsg:
+		if !s.focused {
+			return s, nil
+		}
+
+		switch msg.String() {
+		case "left", "up":
+			return s.resize(s.Size-1, false)
+		case "right", "down":
+			return s.resize(s.Size+1, false)
+		}
+	}
+
+	return s, nil
+}
+
+// resize sets the size of the first pane, returning a command which sends a
+// SplitResizedMsg if the size changed.
+func (s Split) resize(size int, dragging bool) (Split, tea.Cmd) {
+	size = s.clamp(size)
+	if size == s.Size {
+		return s, nil
+	}
+
+	s.Size = size
+	return s, cmd(SplitResizedMsg{ID: s.id, Size: s.Size, Dragging: dragging})
+}
+
+// View renders the split, with first and second as the content of the panes.
+// The content is padded or truncated to fit the size of each pane.
+func (s Split) View(first, second string) string {
+	style := s.Styles.Divider
+	switch {
+	case s.dragging != nil:
+		style = s.Styles.DraggingDivider
+	case s.focused:
+		style = s.Styles.FocusedDivider
+	}
+
+	fw, fh := s.FirstSize()
+	sw, sh := s.SecondSize()
+
+	if s.Orientation == SplitVertical {
+		divider := s.manager.MarkWith(
+			s.DividerID(),
+			style.Render(strings.Repeat(s.Styles.VerticalChar, s.Width)),
+			zone.WithPointer(zone.PointerResizeNS),
+			zone.WithNonSelectable(),
+		)
+
+		return lipgloss.JoinVertical(lipgloss.Left, pane(first, fw, fh), divider, pane(second, sw, sh))
+	}
+
+	divider := s.manager.MarkWith(
+		s.DividerID(),
+		style.Render(strings.TrimSuffix(strings.Repeat(s.Styles.HorizontalChar+"\n", s.Height), "\n")),
+		zone.WithPointer(zone.PointerResizeEW),
+		zone.WithNonSelectable(),
+	)
+
+	return lipgloss.JoinHorizontal(lipgloss.Top, pane(first, fw, fh), divider, pane(second, sw, sh))
+}
+
+// pane renders content padded or truncated to exactly width by height cells.
+// Lines are truncated rather than wrapped, to preserve the layout of the content.
+func pane(content string, width, height int) string {
+	if width <= 0 || height <= 0 {
+		return ""
+	}
+
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		lines[i] = ansi.Truncate(line, width, "")
+	}
+
+	return lipgloss.NewStyle().
+		Width(width).
+		Height(height).
+		MaxHeight(height).
+		Render(strings.Join(lines, "\n"))
+}
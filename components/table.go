@@ -0,0 +1,325 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package components
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	zone "github.com/lrstanley/bubblezone/v2"
+)
+
+// TableColumn is a column of a table.
+type TableColumn struct {
+	Title string
+	Width int // Width of the column, in cells. Cells are truncated to fit.
+}
+
+// TableHit is the result of a hit test against a table. See Table.Hit().
+type TableHit struct {
+	Row int // Index of the row, or -1 if the hit is on the header.
+	Col int // Index of the column.
+
+	IsHeader       bool // The hit is on the header of the column.
+	IsResizeHandle bool // The hit is on the resize handle (right border) of the column.
+}
+
+// TableCellClickedMsg is sent when a cell (or header) of a table is clicked.
+type TableCellClickedMsg struct {
+	ID  string // ID of the table, see Table.ID().
+	Hit TableHit
+}
+
+// TableSortMsg is sent when the sort order of a table changes, from clicking a
+// header.
+type TableSortMsg struct {
+	ID         string // ID of the table, see Table.ID().
+	Col        int    // Index of the column being sorted by.
+	Descending bool
+}
+
+// TableColumnResizedMsg is sent when a column of a table has been resized by
+// dragging its resize handle.
+type TableColumnResizedMsg struct {
+	ID    string // ID of the table, see Table.ID().
+	Col   int    // Index of the resized column.
+	Width int    // The new width of the column.
+}
+
+// TableStyles are the styles used to render a table.
+type TableStyles struct {
+	Header   lipgloss.Style
+	Cell     lipgloss.Style
+	Selected lipgloss.Style // Style of the cells in the selected row.
+
+	Separator    string // Separator between columns, which is also the resize handle.
+	SortAsc      string // Suffix of the header of the sorted column, when ascending.
+	SortDesc     string // Suffix of the header of the sorted column, when descending.
+	MinColWidth  int    // Minimum width of a column when resizing.
+	ResizeHandle bool   // Allow resizing columns by dragging the separator in the header.
+}
+
+// DefaultTableStyles returns the default styles of a table.
+func DefaultTableStyles() TableStyles {
+	return TableStyles{
+		Header:       lipgloss.NewStyle().Bold(true),
+		Cell:         lipgloss.NewStyle(),
+		Selected:     lipgloss.NewStyle().Foreground(lipgloss.Color("#7D56F4")),
+		Separator:    "│",
+		SortAsc:      " ▲",
+		SortDesc:     " ▼",
+		MinColWidth:  3,
+		ResizeHandle: true,
+	}
+}
+
+// tableResize holds the state of a column being resized.
+type tableResize struct {
+	col   int
+	x     int // X coordinate of the mouse press.
+	width int // Width of the column when the resize started.
+}
+
+// Table is a table where each cell, header and column separator is marked as a
+// zone. Clicking a header sorts the table by that column, clicking a cell selects
+// its row, and dragging a column separator in the header resizes the column.
+// Use Hit() to resolve which part of the table a mouse event is over.
+type Table struct {
+	manager  *zone.Manager
+	id       string
+	resizing *tableResize
+
+	Columns    []TableColumn
+	Rows       [][]string
+	Cursor     int // Index of the selected row.
+	SortCol    int // Index of the column being sorted by, or -1 if unsorted.
+	Descending bool
+	Styles     TableStyles
+}
+
+// NewTable returns a new table with the provided columns and rows.
+func NewTable(manager *zone.Manager, columns []TableColumn, rows [][]string) Table {
+	return Table{
+		manager: manager,
+		id:      manager.NewPrefix(),
+		Columns: columns,
+		Rows:    rows,
+		SortCol: -1,
+		Styles:  DefaultTableStyles(),
+	}
+}
+
+// ID returns the unique ID of the table, which is used as the prefix of the zone
+// IDs of the table.
+func (t Table) ID() string {
+	return t.id
+}
+
+// CellID returns the zone ID of the cell at the provided row and column.
+func (t Table) CellID(row, col int) string {
+	return t.id + "c" + strconv.Itoa(row) + "_" + strconv.Itoa(col)
+}
+
+// HeaderID returns the zone ID of the header of the provided column.
+func (t Table) HeaderID(col int) string {
+	return t.id + "h" + strconv.Itoa(col)
+}
+
+// ResizeID returns the zone ID of the resize handle of the provided column.
+func (t Table) ResizeID(col int) string {
+	return t.id + "r" + strconv.Itoa(col)
+}
+
+// Hit returns which part of the table the mouse event is over. If the mouse event
+// isn't over the table, ok is false. While a column is being resized, the resize
+// handle of that column is always returned.
+func (t Table) Hit(msg tea.MouseMsg) (hit TableHit, ok bool) {
+	if t.resizing != nil {
+		return TableHit{Row: -1, Col: t.resizing.col, IsHeader: true, IsResizeHandle: true}, true
+	}
+
+	for col := range t.Columns {
+		if t.manager.Get(t.ResizeID(col)).InBounds(msg) {
+			return TableHit{Row: -1, Col: col, IsHeader: true, IsResizeHandle: true}, true
+		}
+
+		if t.manager.Get(t.HeaderID(col)).InBounds(msg) {
+			return TableHit{Row: -1, Col: col, IsHeader: true}, true
+		}
+	}
+
+	row := t.rowAt(msg)
+	if row == -1 {
+		return hit, false
+	}
+
+	for col := range t.Columns {
+		if t.manager.Get(t.CellID(row, col)).InBounds(msg) {
+			return TableHit{Row: row, Col: col}, true
+		}
+	}
+
+	return hit, false
+}
+
+// rowAt returns the row which the mouse event is on, based on the position of
+// the cells of the first column, or -1 if it isn't on a row. Each row is a
+// single line, so only the cells of that row have to be checked.
+func (t Table) rowAt(msg tea.MouseMsg) int {
+	if len(t.Rows) == 0 || len(t.Columns) == 0 {
+		return -1
+	}
+
+	first := t.manager.Get(t.CellID(0, 0))
+	if first.IsZero() {
+		return -1
+	}
+
+	if row := msg.Mouse().Y - first.StartY; row >= 0 && row < len(t.Rows) {
+		return row
+	}
+	return -1
+}
+
+// SortBy sorts the rows by the provided column. The cursor is moved with the
+// selected row.
+func (t *Table) SortBy(col int, descending bool) {
+	t.SortCol = col
+	t.Descending = descending
+
+	order := make([]int, len(t.Rows))
+	for i := range order {
+		order[i] = i
+	}
+
+	sort.SliceStable(order, func(i, j int) bool {
+		a, b := cellAt(t.Rows[order[i]], col), cellAt(t.Rows[order[j]], col)
+		if descending {
+			return a > b
+		}
+		return a < b
+	})
+
+	rows := make([][]string, len(t.Rows))
+	cursor := t.Cursor
+	for i, row := range order {
+		rows[i] = t.Rows[row]
+		if row == t.Cursor {
+			cursor = i
+		}
+	}
+
+	t.Rows, t.Cursor = rows, cursor
+}
+
+// Update handles mouse events, returning a command which sends a
+// TableCellClickedMsg, TableSortMsg or TableColumnResizedMsg as appropriate.
+func (t Table) Update(msg tea.Msg) (Table, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.MouseClickMsg:
+		if msg.Button != tea.MouseLeft {
+			return t, nil
+		}
+
+		if hit, ok := t.Hit(msg); ok && hit.IsResizeHandle {
+			t.resizing = &tableResize{col: hit.Col, x: msg.X, width: t.Columns[hit.Col].Width}
+			t.manager.Capture(t.ResizeID(hit.Col))
+		}
+	case tea.MouseMotionMsg:
+		if t.resizing == nil {
+			return t, nil
+		}
+
+		t.Columns[t.resizing.col].Width = max(t.Styles.MinColWidth, 1, t.resizing.width+msg.X-t.resizing.x)
+	case tea.MouseReleaseMsg:
+		if msg.Button != tea.MouseLeft {
+			return t, nil
+		}
+
+		if t.resizing != nil {
+			col := t.resizing.col
+			t.resizing = nil
+			t.manager.Release()
+
+			return t, cmd(TableColumnResizedMsg{ID: t.id, Col: col, Width: t.Columns[col].Width})
+		}
+
+		hit, ok := t.Hit(msg)
+		if !ok {
+			return t, nil
+		}
+
+		if hit.IsHeader {
+			t.SortBy(hit.Col, t.SortCol == hit.Col && !t.Descending)
+
+			return t, tea.Batch(
+				cmd(TableCellClickedMsg{ID: t.id, Hit: hit}),
+				cmd(TableSortMsg{ID: t.id, Col: t.SortCol, Descending: t.Descending}),
+			)
+		}
+
+		t.Cursor = hit.Row
+		return t, cmd(TableCellClickedMsg{ID: t.id, Hit: hit})
+	}
+
+	return t, nil
+}
+
+// View renders the table.
+func (t Table) View() string {
+	lines := make([]string, 0, len(t.Rows)+1)
+
+	header := make([]string, len(t.Columns))
+	for col, column := range t.Columns {
+		title := column.Title
+		if col == t.SortCol {
+			if t.Descending {
+				title += t.Styles.SortDesc
+			} else {
+				title += t.Styles.SortAsc
+			}
+		}
+
+		header[col] = t.manager.Mark(t.HeaderID(col), fit(t.Styles.Header, title, column.Width))
+
+		if t.Styles.ResizeHandle {
+			header[col] += t.manager.MarkWith(t.ResizeID(col), t.Styles.Separator, zone.WithNonSelectable())
+		} else {
+			header[col] += t.Styles.Separator
+		}
+	}
+	lines = append(lines, strings.Join(header, ""))
+
+	for row, cells := range t.Rows {
+		style := t.Styles.Cell
+		if row == t.Cursor {
+			style = t.Styles.Selected
+		}
+
+		line := make([]string, len(t.Columns))
+		for col, column := range t.Columns {
+			line[col] = t.manager.Mark(t.CellID(row, col), fit(style, cellAt(cells, col), column.Width)) + t.Styles.Separator
+		}
+		lines = append(lines, strings.Join(line, ""))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// fit renders s with style, padded or truncated to exactly width cells.
+func fit(style lipgloss.Style, s string, width int) string {
+	return style.Inline(true).Width(width).MaxWidth(width).Render(s)
+}
+
+// cellAt returns the cell at col, or an empty string if the row is too short.
+func cellAt(cells []string, col int) string {
+	if col < 0 || col >= len(cells) {
+		return ""
+	}
+	return cells[col]
+}
@@ -0,0 +1,177 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package components
+
+import (
+	"strconv"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	zone "github.com/lrstanley/bubblezone/v2"
+)
+
+// TabChangedMsg is sent when the active tab of a tab bar changes.
+type TabChangedMsg struct {
+	ID    string // ID of the tab bar, see Tabs.ID().
+	Index int    // Index of the newly active tab.
+	Tab   string // The newly active tab.
+}
+
+// TabStyles are the styles used to render a tab bar.
+type TabStyles struct {
+	Tab     lipgloss.Style // Style of each inactive tab.
+	Active  lipgloss.Style // Style of the active tab.
+	Focused lipgloss.Style // Style of the active tab, when focused.
+	Gap     lipgloss.Style // Style of the gap after the last tab, filling the width.
+}
+
+// DefaultTabStyles returns the default styles of a tab bar.
+func DefaultTabStyles() TabStyles {
+	highlight := lipgloss.Color("#7D56F4")
+
+	tab := lipgloss.NewStyle().
+		Border(lipgloss.Border{
+			Top: "─", Bottom: "─", Left: "│", Right: "│",
+			TopLeft: "╭", TopRight: "╮", BottomLeft: "┴", BottomRight: "┴",
+		}, true).
+		BorderForeground(highlight).
+		Padding(0, 1)
+
+	active := tab.Border(lipgloss.Border{
+		Top: "─", Bottom: " ", Left: "│", Right: "│",
+		TopLeft: "╭", TopRight: "╮", BottomLeft: "┘", BottomRight: "└",
+	}, true)
+
+	return TabStyles{
+		Tab:     tab,
+		Active:  active,
+		Focused: active.Bold(true),
+		Gap:     tab.BorderTop(false).BorderLeft(false).BorderRight(false),
+	}
+}
+
+// Tabs is a tab bar, where tabs can be activated by clicking them, or with the
+// left/right arrow keys while focused.
+type Tabs struct {
+	manager *zone.Manager
+	id      string
+	focused bool
+
+	Tabs   []string
+	Active int
+	Width  int // If set, the remaining width after the last tab is filled using the gap style.
+	Styles TabStyles
+}
+
+// NewTabs returns a new tab bar with the provided tabs, with the first tab
+// active.
+func NewTabs(manager *zone.Manager, tabs ...string) Tabs {
+	return Tabs{
+		manager: manager,
+		id:      manager.NewPrefix(),
+		Tabs:    tabs,
+		Styles:  DefaultTabStyles(),
+	}
+}
+
+// ID returns the unique ID of the tab bar, which is used as the prefix of the
+// zone ID of each tab.
+func (t Tabs) ID() string {
+	return t.id
+}
+
+// Focus focuses the tab bar, allowing tabs to be activated with the keyboard.
+func (t *Tabs) Focus() {
+	t.focused = true
+}
+
+// Blur removes focus from the tab bar.
+func (t *Tabs) Blur() {
+	t.focused = false
+}
+
+// Focused returns true if the tab bar is focused.
+func (t Tabs) Focused() bool {
+	return t.focused
+}
+
+// Value returns the active tab, or an empty string if there are no tabs.
+func (t Tabs) Value() string {
+	if t.Active < 0 || t.Active >= len(t.Tabs) {
+		return ""
+	}
+	return t.Tabs[t.Active]
+}
+
+// tabID returns the zone ID of the tab at index i.
+func (t Tabs) tabID(i int) string {
+	return t.id + strconv.Itoa(i)
+}
+
+// Update handles mouse and keyboard events, returning a command which sends a
+// TabChangedMsg when the active tab changes.
+func (t Tabs) Update(msg tea.Msg) (Tabs, tea.Cmd) {
+	if len(t.Tabs) == 0 {
+		return t, nil
+	}
+
+	active := t.Active
+
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		t.Width = msg.Width
+	case tea.MouseReleaseMsg:
+		for i := range t.Tabs {
+			if clicked(t.manager, t.tabID(i), msg) {
+				active = i
+				break
+			}
+		}
+	case tea.KeyPressMsg:
+		if !t.focused {
+			return t, nil
+		}
+
+		switch msg.String() {
+		case "left", "shift+tab":
+			active = (active - 1 + len(t.Tabs)) % len(t.Tabs)
+		case "right", "tab":
+			active = (active + 1) % len(t.Tabs)
+		}
+	}
+
+	if active == t.Active {
+		return t, nil
+	}
+
+	t.Active = active
+	return t, cmd(TabChangedMsg{ID: t.id, Index: t.Active, Tab: t.Value()})
+}
+
+// View renders the tab bar.
+func (t Tabs) View() string {
+	tabs := make([]string, len(t.Tabs))
+
+	for i, tab := range t.Tabs {
+		style := t.Styles.Tab
+		if i == t.Active {
+			style = t.Styles.Active
+			if t.focused {
+				style = t.Styles.Focused
+			}
+		}
+
+		tabs[i] = t.manager.Mark(t.tabID(i), style.Render(tab))
+	}
+
+	row := lipgloss.JoinHorizontal(lipgloss.Top, tabs...)
+	if t.Width == 0 {
+		return row
+	}
+
+	gap := t.Styles.Gap.Render(strings.Repeat(" ", max(0, t.Width-lipgloss.Width(row)-t.Styles.Gap.GetHorizontalFrameSize())))
+	return lipgloss.JoinHorizontal(lipgloss.Bottom, row, gap)
+}
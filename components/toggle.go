@@ -0,0 +1,111 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package components
+
+import (
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	zone "github.com/lrstanley/bubblezone/v2"
+)
+
+// ToggleChangedMsg is sent when a toggle is switched on or off.
+type ToggleChangedMsg struct {
+	ID string // ID of the toggle, see Toggle.ID().
+	On bool   // The new state of the toggle.
+}
+
+// ToggleStyles are the styles used to render a toggle.
+type ToggleStyles struct {
+	Label        lipgloss.Style
+	FocusedLabel lipgloss.Style
+	On           lipgloss.Style
+	Off          lipgloss.Style
+
+	OnText  string // Text shown in the switch when on.
+	OffText string // Text shown in the switch when off.
+}
+
+// DefaultToggleStyles returns the default styles of a toggle.
+func DefaultToggleStyles() ToggleStyles {
+	return ToggleStyles{
+		Label:        lipgloss.NewStyle(),
+		FocusedLabel: lipgloss.NewStyle().Foreground(lipgloss.Color("#7D56F4")),
+		On:           lipgloss.NewStyle().Foreground(lipgloss.Color("#1A1A1A")).Background(lipgloss.Color("#73F59F")).Padding(0, 1),
+		Off:          lipgloss.NewStyle().Foreground(lipgloss.Color("#FFF7DB")).Background(lipgloss.Color("#383838")).Padding(0, 1),
+		OnText:       "ON ",
+		OffText:      "OFF",
+	}
+}
+
+// Toggle is a clickable on/off switch, which can also be switched with the enter
+// or space keys while focused.
+type Toggle struct {
+	manager *zone.Manager
+	id      string
+	focused bool
+
+	Label  string
+	On     bool
+	Styles ToggleStyles
+}
+
+// NewToggle returns a new toggle with the provided label, which is off.
+func NewToggle(manager *zone.Manager, label string) Toggle {
+	return Toggle{
+		manager: manager,
+		id:      manager.NewPrefix(),
+		Label:   label,
+		Styles:  DefaultToggleStyles(),
+	}
+}
+
+// ID returns the unique ID of the toggle, which is also used as its zone ID.
+func (t Toggle) ID() string {
+	return t.id
+}
+
+// Focus focuses the toggle, allowing it to be switched with the keyboard.
+func (t *Toggle) Focus() {
+	t.focused = true
+}
+
+// Blur removes focus from the toggle.
+func (t *Toggle) Blur() {
+	t.focused = false
+}
+
+// Focused returns true if the toggle is focused.
+func (t Toggle) Focused() bool {
+	return t.focused
+}
+
+// Update handles mouse and keyboard events, switching the toggle and returning
+// a command which sends a ToggleChangedMsg when it is switched.
+func (t Toggle) Update(msg tea.Msg) (Toggle, tea.Cmd) {
+	if clicked(t.manager, t.id, msg) || activated(t.focused, msg) {
+		t.On = !t.On
+		return t, cmd(ToggleChangedMsg{ID: t.id, On: t.On})
+	}
+	return t, nil
+}
+
+// View renders the toggle.
+func (t Toggle) View() string {
+	label := t.Styles.Label
+	if t.focused {
+		label = t.Styles.FocusedLabel
+	}
+
+	state := t.Styles.Off.Render(t.Styles.OffText)
+	if t.On {
+		state = t.Styles.On.Render(t.Styles.OnText)
+	}
+
+	if t.Label == "" {
+		return t.manager.Mark(t.id, state)
+	}
+
+	return t.manager.Mark(t.id, state+" "+label.Render(t.Label))
+}
@@ -0,0 +1,144 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package zone
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// annotateKeys are the characters used to label zones in Annotate(), in order.
+// Zones beyond the available keys are labeled with annotateOverflow.
+const (
+	annotateKeys     = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789"
+	annotateOverflow = '#'
+	annotateEmpty    = '.'
+)
+
+// Zones returns all zones of the last scanned view, sorted by ID.
+func (m *Manager) Zones() []*ZoneInfo {
+	m.zoneMu.RLock()
+	defer m.zoneMu.RUnlock()
+
+	zones := make([]*ZoneInfo, 0, len(m.zones))
+	for _, zone := range m.zones {
+		zones = append(zones, zone)
+	}
+
+	sort.Slice(zones, func(i, j int) bool {
+		return zones[i].name < zones[j].name
+	})
+	return zones
+}
+
+// Annotate returns a plain text image of view (which should be the output of
+// Scan()) annotated with the zones of the last scanned view, which is useful to
+// debug zone layouts, and for golden file tests (see the zonetest package).
+//
+// The result contains three sections: the view with all styling stripped, a map
+// of the same size where each cell is labeled with the key of the innermost zone
+// covering it ('.' if none), and a legend listing the key, ID and bounds of each
+// zone, sorted by ID. Cells are measured with the width function of the manager
+// (see WithWidthFunc()), like zones, padding characters of the view which are
+// wider than rendered. Zones with invalid bounds (e.g. a zone spanning multiple
+// lines which ends at a lower column than it starts) aren't drawn on the map,
+// and are flagged in the legend.
+func (m *Manager) Annotate(view string) string {
+	lines := strings.Split(ansi.Strip(view), "\n")
+	zones := m.Zones()
+
+	width, height := 0, len(lines)
+	for i, line := range lines {
+		lines[i] = m.align(line)
+		width = max(width, m.width(line))
+	}
+	for _, zone := range zones {
+		width = max(width, zone.EndX+1)
+		height = max(height, zone.EndY+1)
+	}
+
+	keys := make(map[*ZoneInfo]rune, len(zones))
+	for i, zone := range zones {
+		keys[zone] = annotateOverflow
+		if i < len(annotateKeys) {
+			keys[zone] = rune(annotateKeys[i])
+		}
+	}
+
+	grid := make([][]rune, height)
+	for y := range grid {
+		grid[y] = []rune(strings.Repeat(string(annotateEmpty), width))
+	}
+
+	// Draw outer zones first, so inner zones are drawn on top.
+	painted := append([]*ZoneInfo(nil), zones...)
+	sort.SliceStable(painted, func(i, j int) bool {
+		return painted[i].depth < painted[j].depth
+	})
+
+	for _, zone := range painted {
+		if !validBounds(zone) {
+			continue
+		}
+
+		for y := max(0, zone.StartY); y <= zone.EndY; y++ {
+			for x := max(0, zone.StartX); x <= zone.EndX; x++ {
+				grid[y][x] = keys[zone]
+			}
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(strings.Join(lines, "\n"))
+	b.WriteString("\n--- zones ---\n")
+
+	for _, row := range grid {
+		b.WriteString(string(row))
+		b.WriteByte('\n')
+	}
+
+	b.WriteString("--- legend ---\n")
+	for _, zone := range zones {
+		fmt.Fprintf(&b, "%c %s (%d,%d)-(%d,%d)", keys[zone], zone.name, zone.StartX, zone.StartY, zone.EndX, zone.EndY)
+		if zone.depth > 0 {
+			fmt.Fprintf(&b, " depth=%d", zone.depth)
+		}
+		if !validBounds(zone) {
+			b.WriteString(" invalid-bounds")
+		}
+		b.WriteByte('\n')
+	}
+
+	return b.String()
+}
+
+// align pads each grapheme cluster of line which is wider according to the width
+// function of the manager (see WithWidthFunc()) than it is rendered, so each
+// character of the line is in the same column as the cells of its zones.
+func (m *Manager) align(line string) string {
+	var b strings.Builder
+	var state byte
+
+	for len(line) > 0 {
+		seq, width, n, newState := ansi.DecodeSequence(line, state, nil)
+		state = newState
+		line = line[n:]
+
+		b.WriteString(seq)
+		if width > 0 {
+			b.WriteString(strings.Repeat(" ", max(0, m.width(seq)-width)))
+		}
+	}
+
+	return b.String()
+}
+
+// validBounds returns true if the start of the zone is before its end.
+func validBounds(zone *ZoneInfo) bool {
+	return zone.StartX <= zone.EndX && zone.StartY <= zone.EndY && zone.EndX >= 0 && zone.EndY >= 0
+}
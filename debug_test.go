@@ -0,0 +1,62 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package zone
+
+import (
+	"testing"
+	"unicode/utf8"
+
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestAnnotate(t *testing.T) {
+	zm := New()
+	defer zm.Close()
+
+	view := zm.Scan(
+		lipgloss.NewStyle().Bold(true).Render("title") + "\n" +
+			zm.Mark("outer", "ab"+zm.Mark("inner", "cd")+"ef") + " " + zm.Mark("btn", "[x]") + "\n" +
+			"xx" + zm.Mark("broken", "a\nb"),
+	)
+	zm.Flush()
+
+	want := `title
+abcdef [x]
+xxa
+b
+--- zones ---
+..........
+DDCCDD.BBB
+..........
+..........
+--- legend ---
+A broken (2,2)-(0,3) invalid-bounds
+B btn (7,1)-(9,1)
+C inner (2,1)-(3,1) depth=1
+D outer (0,1)-(5,1)
+`
+
+	if got := zm.Annotate(view); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestAnnotateWidthFunc(t *testing.T) {
+	// Every rune is two cells wide.
+	zm := New(WithSyncCommit(true), WithWidthFunc(func(s string) int {
+		return 2 * utf8.RuneCountInString(ansi.Strip(s))
+	}))
+	defer zm.Close()
+
+	view := zm.Scan("a" + zm.Mark("b", "b") + "c")
+
+	// The view is padded, so each character is above its cells.
+	want := "a b c \n--- zones ---\n..AA..\n--- legend ---\nA b (2,0)-(3,0)\n"
+
+	if got := zm.Annotate(view); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
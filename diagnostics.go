@@ -0,0 +1,136 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package zone
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+)
+
+// DiagnosticKind is the kind of problem found while scanning a view.
+type DiagnosticKind int
+
+const (
+	// DiagnosticDuplicateID means the same ID was marked more than once in the
+	// same view. Only the last zone with the ID is stored.
+	DiagnosticDuplicateID DiagnosticKind = iota
+
+	// DiagnosticUnbalanced means a zone marker had no matching end marker (e.g.
+	// the output of Mark() was truncated), so the zone was dropped.
+	DiagnosticUnbalanced
+
+	// DiagnosticZeroWidth means a zone has no printable content, so it can't be
+	// in the bounds of any mouse event.
+	DiagnosticZeroWidth
+
+	// DiagnosticInvalidBounds means a zone spanning multiple lines ends at a
+	// lower column than it starts (StartX > EndX), so it can't be in the bounds
+	// of any mouse event. See the "Organic shapes" section of the README.
+	DiagnosticInvalidBounds
+)
+
+// String returns a short description of the kind of diagnostic.
+func (k DiagnosticKind) String() string {
+	switch k {
+	case DiagnosticDuplicateID:
+		return "duplicate ID"
+	case DiagnosticUnbalanced:
+		return "unbalanced marker"
+	case DiagnosticZeroWidth:
+		return "zero-width zone"
+	case DiagnosticInvalidBounds:
+		return "invalid bounds"
+	default:
+		return fmt.Sprintf("DiagnosticKind(%d)", int(k))
+	}
+}
+
+// Diagnostic is a problem with a zone, found while scanning a view. See
+// Manager.Diagnostics().
+type Diagnostic struct {
+	Kind DiagnosticKind
+	ID   string // ID which was provided when marking the zone.
+
+	X int // X is the x coordinate of the start of the zone (with 0 basis).
+	Y int // Y is the y coordinate of the start of the zone (with 0 basis).
+}
+
+// String returns a description of the diagnostic.
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%s: %q at (%d, %d)", d.Kind, d.ID, d.X, d.Y)
+}
+
+// diagnose returns the diagnostics for a completed zone, where seen contains
+// the IDs of the zones already completed in the same view.
+func diagnose(zone *ZoneInfo, seen map[string]bool) (diags []Diagnostic) {
+	newDiag := func(kind DiagnosticKind) Diagnostic {
+		return Diagnostic{Kind: kind, ID: zone.name, X: zone.StartX, Y: zone.StartY}
+	}
+
+	if seen[zone.id] {
+		diags = append(diags, newDiag(DiagnosticDuplicateID))
+	}
+
+	switch {
+	case zone.StartY == zone.EndY && zone.EndX < zone.StartX:
+		diags = append(diags, newDiag(DiagnosticZeroWidth))
+	case zone.EndX < zone.StartX:
+		diags = append(diags, newDiag(DiagnosticInvalidBounds))
+	}
+
+	return diags
+}
+
+// Diagnostics returns the problems found while scanning the last view, like
+// duplicate IDs or unbalanced markers. See DiagnosticKind for all problems which
+// are detected. Diagnostics are only collected while the manager is enabled.
+func (m *Manager) Diagnostics() []Diagnostic {
+	m.diagMu.Lock()
+	defer m.diagMu.Unlock()
+	return append([]Diagnostic(nil), m.diagnostics...)
+}
+
+// SetLogger sets the logger used by the manager (see WithLogger()), replacing
+// any logger provided to New(). Diagnostics (see Diagnostics()) are logged as
+// warnings, however only diagnostics which weren't found in the previous view
+// are logged, so the same problem isn't logged on every render. If logger is
+// nil (the default), nothing is logged.
+func (m *Manager) SetLogger(logger *slog.Logger) {
+	m.diagMu.Lock()
+	m.logger = logger
+	m.diagMu.Unlock()
+}
+
+// setDiagnostics stores the diagnostics of the last scanned view, logging any
+// new diagnostics.
+func (m *Manager) setDiagnostics(diags []Diagnostic) {
+	m.diagMu.Lock()
+	defer m.diagMu.Unlock()
+
+	if m.logger != nil {
+		previous := make(map[Diagnostic]bool, len(m.diagnostics))
+		for _, diag := range m.diagnostics {
+			previous[diag] = true
+		}
+
+		for _, diag := range diags {
+			if previous[diag] {
+				continue
+			}
+
+			m.logger.LogAttrs(
+				context.Background(),
+				slog.LevelWarn,
+				"zone: "+diag.Kind.String(),
+				slog.String("id", diag.ID),
+				slog.Int("x", diag.X),
+				slog.Int("y", diag.Y),
+			)
+		}
+	}
+
+	m.diagnostics = diags
+}
@@ -0,0 +1,73 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package zone
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestDiagnostics(t *testing.T) {
+	zm := New()
+	defer zm.Close()
+
+	var buf bytes.Buffer
+	zm.SetLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, attr slog.Attr) slog.Attr {
+			if attr.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return attr
+		},
+	})))
+
+	cut := zm.Mark("cut", "xyz")
+	cut = cut[:strings.Index(cut, "xyz")+3]
+
+	view := zm.Mark("ok", "ok") + " " + zm.Mark("dup", "a") + " " + zm.Mark("dup", "b") + "\n" +
+		zm.Mark("empty", "\x1b[1m\x1b[0m") + "abc " + zm.Mark("multi", "def\ng") + " " + cut
+
+	if got := zm.Scan(view); got != "ok a b\n\x1b[1m\x1b[0mabc def\ng xyz" {
+		t.Fatalf("got view %q", got)
+	}
+
+	want := []Diagnostic{
+		{Kind: DiagnosticDuplicateID, ID: "dup", X: 5, Y: 0},
+		{Kind: DiagnosticZeroWidth, ID: "empty", X: 0, Y: 1},
+		{Kind: DiagnosticInvalidBounds, ID: "multi", X: 4, Y: 1},
+		{Kind: DiagnosticUnbalanced, ID: "cut", X: 2, Y: 2},
+	}
+
+	got := zm.Diagnostics()
+	if len(got) != len(want) {
+		t.Fatalf("got diagnostics %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("diagnostic %d: got %v, want %v", i, got[i], want[i])
+		}
+	}
+
+	if lines := strings.Split(strings.TrimSpace(buf.String()), "\n"); len(lines) != len(want) {
+		t.Fatalf("got %d log lines, want %d:\n%s", len(lines), len(want), buf.String())
+	}
+	if want := `level=WARN msg="zone: duplicate ID" id=dup x=5 y=0`; !strings.HasPrefix(buf.String(), want+"\n") {
+		t.Errorf("got log %q, want prefix %q", buf.String(), want)
+	}
+
+	// The same diagnostics aren't logged again for the next view.
+	buf.Reset()
+	_ = zm.Scan(view)
+	if buf.Len() != 0 {
+		t.Errorf("expected no new log lines, got %q", buf.String())
+	}
+
+	_ = zm.Scan(zm.Mark("ok", "ok"))
+	if got := zm.Diagnostics(); len(got) != 0 {
+		t.Errorf("expected no diagnostics, got %v", got)
+	}
+}
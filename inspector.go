@@ -0,0 +1,211 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package zone
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// InspectorStyles are the styles used by the zone inspector. See
+// Manager.SetInspectorStyles().
+type InspectorStyles struct {
+	// Zones are the styles of cells within zones, cycled by how deeply the zone
+	// is nested, so nested zones can be told apart.
+	Zones   []lipgloss.Style
+	Hovered lipgloss.Style // Style of cells within the innermost zone under the mouse.
+	Status  lipgloss.Style // Style of the status line.
+}
+
+// DefaultInspectorStyles are the default styles used by the zone inspector.
+var DefaultInspectorStyles = InspectorStyles{
+	Zones: []lipgloss.Style{
+		lipgloss.NewStyle().Background(lipgloss.Color("#2B2B45")),
+		lipgloss.NewStyle().Background(lipgloss.Color("#3D3D63")),
+		lipgloss.NewStyle().Background(lipgloss.Color("#505082")),
+	},
+	Hovered: lipgloss.NewStyle().Background(lipgloss.Color("#7D56F4")).Foreground(lipgloss.Color("#FFFFFF")),
+	Status:  lipgloss.NewStyle().Background(lipgloss.Color("#F25D94")).Foreground(lipgloss.Color("#FFFFFF")),
+}
+
+// inspectorState holds the state of the zone inspector.
+type inspectorState struct {
+	enabled bool
+	styles  InspectorStyles
+	mouse   *cell // Position of the last mouse event, if any.
+}
+
+// SetInspector enables or disables the zone inspector, which is useful to debug
+// zone layouts in a running application, without changing any components. While
+// enabled, Scan() paints the bounds of each zone of the view with a background,
+// and draws a status line listing the zone under the mouse (see
+// UpdateInspector()), and any other zones overlapping it. The status line is
+// drawn over the last line of the view, or the first line if the mouse is on the
+// last line.
+//
+// Note that the original styling of cells within zones is replaced while the
+// inspector is enabled. The inspector is disabled by default. Use
+// WithInspectorKey() to toggle the inspector with a key in wrapped models.
+func (m *Manager) SetInspector(enabled bool) {
+	m.inspectorMu.Lock()
+	m.inspector.enabled = enabled
+	m.inspectorMu.Unlock()
+}
+
+// Inspecting returns true if the zone inspector is enabled. See SetInspector().
+func (m *Manager) Inspecting() bool {
+	m.inspectorMu.Lock()
+	defer m.inspectorMu.Unlock()
+	return m.inspector.enabled
+}
+
+// SetInspectorStyles sets the styles used by the zone inspector. Defaults to
+// DefaultInspectorStyles.
+func (m *Manager) SetInspectorStyles(styles InspectorStyles) {
+	m.inspectorMu.Lock()
+	m.inspector.styles = styles
+	m.inspectorMu.Unlock()
+}
+
+// UpdateInspector tracks the position of the mouse, which the zone inspector
+// uses to highlight the zone under the mouse (see SetInspector()). msg can be
+// any message, however only mouse events are used.
+//
+// Wrapped models (see Wrap()) call UpdateInspector() automatically.
+func (m *Manager) UpdateInspector(msg tea.Msg) {
+	mouse, ok := msg.(tea.MouseMsg)
+	if !ok {
+		return
+	}
+
+	event := mouse.Mouse()
+
+	m.inspectorMu.Lock()
+	m.inspector.mouse = &cell{x: event.X, y: event.Y}
+	m.inspectorMu.Unlock()
+}
+
+// inspect paints the zones of a scanned view, and draws the status line, if the
+// inspector is enabled.
+func (m *Manager) inspect(view string, zones []*ZoneInfo) string {
+	m.inspectorMu.Lock()
+	state := m.inspector
+	m.inspectorMu.Unlock()
+
+	if !state.enabled {
+		return view
+	}
+
+	// Paint outer zones first, so inner zones are painted on top.
+	zones = append([]*ZoneInfo(nil), zones...)
+	sort.SliceStable(zones, func(i, j int) bool {
+		return zones[i].depth < zones[j].depth
+	})
+
+	var hovered []*ZoneInfo
+	if state.mouse != nil {
+		for _, zone := range zones {
+			if validBounds(zone) && zone.InBounds(tea.MouseMotionMsg{X: state.mouse.x, Y: state.mouse.y}) {
+				hovered = append(hovered, zone)
+			}
+		}
+		hovered = innermost(hovered)
+	}
+
+	palette := append(append([]lipgloss.Style(nil), state.styles.Zones...), state.styles.Hovered)
+
+	lines := strings.Split(view, "\n")
+	width := 0
+	for y, line := range lines {
+		w := m.width(line)
+		width = max(width, w)
+
+		cells := make([]int, w)
+		for x := range cells {
+			cells[x] = -1
+		}
+
+		for _, zone := range zones {
+			if !validBounds(zone) || y < zone.StartY || y > zone.EndY {
+				continue
+			}
+
+			style := len(palette) - 1
+			if len(hovered) == 0 || zone != hovered[0] {
+				if len(state.styles.Zones) == 0 {
+					continue
+				}
+				style = zone.depth % len(state.styles.Zones)
+			}
+
+			for x := max(0, zone.StartX); x <= zone.EndX && x < w; x++ {
+				cells[x] = style
+			}
+		}
+
+		lines[y] = m.paint(line, cells, palette)
+	}
+
+	status := Overlay{Content: state.styles.Status.Render(m.cut(inspectStatus(state.mouse, zones, hovered), 0, width))}
+	if state.mouse == nil || state.mouse.y != len(lines)-1 {
+		status.Y = len(lines) - 1
+	}
+
+	return status.Composite(strings.Join(lines, "\n"))
+}
+
+// paint restyles each run of cells of line with the same style, where cells is
+// the index of the style in palette for each cell, or -1 to keep the cell as-is.
+func (m *Manager) paint(line string, cells []int, palette []lipgloss.Style) string {
+	var b strings.Builder
+
+	for start := 0; start < len(cells); {
+		end := start + 1
+		for end < len(cells) && cells[end] == cells[start] {
+			end++
+		}
+
+		segment := m.cut(line, start, end)
+		if cells[start] >= 0 {
+			segment = palette[cells[start]].Render(ansi.Strip(segment))
+		}
+		b.WriteString(segment)
+
+		start = end
+	}
+
+	return b.String()
+}
+
+// inspectStatus returns the text of the inspector status line.
+func inspectStatus(mouse *cell, zones, hovered []*ZoneInfo) string {
+	status := fmt.Sprintf(" zones: %d", len(zones))
+	if mouse == nil {
+		return status + " "
+	}
+
+	status += fmt.Sprintf(" | (%d,%d): ", mouse.x, mouse.y)
+	if len(hovered) == 0 {
+		return status + "no zone "
+	}
+
+	zone := hovered[0]
+	status += fmt.Sprintf("%s (%d,%d)-(%d,%d)", zone.name, zone.StartX, zone.StartY, zone.EndX, zone.EndY)
+
+	if len(hovered) > 1 {
+		names := make([]string, 0, len(hovered)-1)
+		for _, zone := range hovered[1:] {
+			names = append(names, zone.name)
+		}
+		status += " | overlapping: " + strings.Join(names, ", ")
+	}
+
+	return status + " "
+}
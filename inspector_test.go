@@ -0,0 +1,116 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package zone
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestInspector(t *testing.T) {
+	zm := New()
+	defer zm.Close()
+
+	zm.SetInspectorStyles(InspectorStyles{
+		Zones:   []lipgloss.Style{lipgloss.NewStyle().Bold(true)},
+		Hovered: lipgloss.NewStyle().Underline(true),
+		Status:  lipgloss.NewStyle(),
+	})
+
+	view := func() string {
+		return "title\n" + zm.Mark("outer", "ab "+zm.Mark("inner", "cd")+" ef") + "\n" + strings.Repeat(" ", 60)
+	}
+
+	if got := zm.Scan(view()); got != "title\nab cd ef\n"+strings.Repeat(" ", 60) {
+		t.Fatalf("expected unchanged view while disabled, got %q", got)
+	}
+
+	zm.SetInspector(true)
+	if !zm.Inspecting() {
+		t.Fatal("expected inspector to be enabled")
+	}
+
+	got := zm.Scan(view())
+	lines := strings.Split(got, "\n")
+	if len(lines) != 3 {
+		t.Fatalf("got %d lines, want 3: %q", len(lines), got)
+	}
+
+	if want := "\x1b[1mab cd ef\x1b[m"; lines[1] != want {
+		t.Errorf("got painted line %q, want %q", lines[1], want)
+	}
+	if want := " zones: 2 "; strings.TrimRight(ansi.Strip(lines[2]), " ") != strings.TrimRight(want, " ") {
+		t.Errorf("got status line %q, want %q", ansi.Strip(lines[2]), want)
+	}
+
+	zm.UpdateInspector(tea.MouseMotionMsg{X: 3, Y: 1})
+	lines = strings.Split(zm.Scan(view()), "\n")
+
+	// The status line is composited over the view, which merges the styles of
+	// adjacent cells.
+	if want := "\x1b[1mab \x1b[22;4mcd\x1b[24;1m ef\x1b[m"; lines[1] != want {
+		t.Errorf("got painted line %q, want %q", lines[1], want)
+	}
+	if want := " zones: 2 | (3,1): inner (3,1)-(4,1) | overlapping: outer "; strings.TrimRight(ansi.Strip(lines[2]), " ") != strings.TrimRight(want, " ") {
+		t.Errorf("got status line %q, want %q", ansi.Strip(lines[2]), want)
+	}
+
+	// The status line moves to the top, when the mouse is on the last line.
+	zm.UpdateInspector(tea.MouseMotionMsg{X: 0, Y: 2})
+	lines = strings.Split(zm.Scan(view()), "\n")
+
+	if want := " zones: 2 | (0,2): no zone "; strings.TrimRight(ansi.Strip(lines[0]), " ") != strings.TrimRight(want, " ") {
+		t.Errorf("got status line %q, want %q", ansi.Strip(lines[0]), want)
+	}
+
+	zm.SetInspector(false)
+	if got := zm.Scan(view()); strings.Contains(got, "zones:") {
+		t.Errorf("expected no status line once disabled, got %q", got)
+	}
+}
+
+func TestInspectorWidthFunc(t *testing.T) {
+	// Every rune is two cells wide.
+	zm := New(WithWidthFunc(func(s string) int {
+		return 2 * utf8.RuneCountInString(ansi.Strip(s))
+	}))
+	defer zm.Close()
+
+	zm.SetInspectorStyles(InspectorStyles{
+		Zones:  []lipgloss.Style{lipgloss.NewStyle().Bold(true)},
+		Status: lipgloss.NewStyle(),
+	})
+	zm.SetInspector(true)
+
+	lines := strings.Split(zm.Scan("ab"+zm.Mark("foo", "cd")+"ef\n"+strings.Repeat(" ", 20)), "\n")
+	if want := "ab\x1b[1mcd\x1b[mef"; lines[0] != want {
+		t.Errorf("got painted line %q, want %q", lines[0], want)
+	}
+}
+
+func TestWrapInspectorKey(t *testing.T) {
+	zm := New()
+	defer zm.Close()
+
+	w := zm.Wrap(testWrapModel{}, WithInspectorKey("f12"))
+
+	w, _ = w.Update(tea.KeyPressMsg{Code: tea.KeyF12})
+	if !zm.Inspecting() {
+		t.Fatal("expected inspector to be enabled")
+	}
+	if msgs := Unwrap(w).(testWrapModel).received; len(msgs) != 0 {
+		t.Errorf("expected key press not to be sent to the model, got %v", msgs)
+	}
+
+	_, _ = w.Update(tea.KeyPressMsg{Code: tea.KeyF12})
+	if zm.Inspecting() {
+		t.Error("expected inspector to be disabled")
+	}
+}
@@ -0,0 +1,34 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package zone
+
+// hyperlink returns text wrapped in an OSC 8 hyperlink sequence, pointing to url.
+func hyperlink(url, text string) string {
+	return "\x1b]8;;" + url + "\x1b\\" + text + "\x1b]8;;\x1b\\"
+}
+
+// withLink attaches the URL of a hyperlink to the zone.
+func withLink(url string) MarkOption {
+	return func(meta *zoneMeta) {
+		meta.link = url
+	}
+}
+
+// MarkLink returns text wrapped in an OSC 8 hyperlink pointing to url, which
+// is also marked as a zone with the provided ID. Terminals which support OSC 8
+// will allow opening the link natively, and the URL is included in zone messages
+// (see ZoneEvent.URL), so clicks can also be handled by the application (e.g. for
+// terminals without native hyperlink support). Wrapped models (see Wrap()) will
+// also send a MsgZoneLink message when the link is clicked.
+//
+// When the zone manager is disabled, MarkLink() will still return the hyperlink,
+// without the zone markers.
+func (m *Manager) MarkLink(id, url, text string, opts ...MarkOption) string {
+	if url == "" {
+		return m.MarkWith(id, text, opts...)
+	}
+
+	return m.MarkWith(id, hyperlink(url, text), append(opts[:len(opts):len(opts)], withLink(url))...)
+}
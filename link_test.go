@@ -0,0 +1,80 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package zone
+
+import (
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+func TestPrintableRuneWidth(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want int
+	}{
+		{"empty", "", 0},
+		{"plain", "abc", 3},
+		{"wide", "日本", 4},
+		{"csi", "\x1b[1;31mabc\x1b[0m", 3},
+		{"marker", "\x1b[1234zabc\x1b[1234z", 3},
+		{"osc8-st", hyperlink("https://example.com/zzz", "abc"), 3},
+		{"osc8-bel", "\x1b]8;;https://example.com/zzz\aabc\x1b]8;;\a", 3},
+		{"osc8-styled", "\x1b[1m" + hyperlink("https://example.com", "abc") + "\x1b[0m d", 5},
+		{"escape-two-char", "\x1b7abc\x1b8", 3},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := printableRuneWidth(test.in); got != test.want {
+				t.Errorf("got %d, want %d", got, test.want)
+			}
+		})
+	}
+}
+
+func TestMarkLink(t *testing.T) {
+	url := "https://example.com/some/long/path?with=query"
+
+	got := Scan("aaa " + MarkLink("link", url, "click") + " " + Mark("after", "b"))
+	if want := "aaa " + hyperlink(url, "click") + " b"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	link := Get("link")
+	if link.IsZero() {
+		t.Fatal("id not found")
+	}
+
+	if link.StartX != 4 || link.EndX != 8 {
+		t.Errorf("got link zone %d-%d, want 4-8", link.StartX, link.EndX)
+	}
+
+	if after := Get("after"); after.StartX != 10 || after.EndX != 10 {
+		t.Errorf("got after zone %d-%d, want 10-10", after.StartX, after.EndX)
+	}
+
+	msgs := DefaultManager.zoneMsgs(tea.MouseReleaseMsg{X: 5, Y: 0, Button: tea.MouseLeft})
+	if len(msgs) != 2 {
+		t.Fatalf("got %d messages, want 2", len(msgs))
+	}
+
+	evt, ok := msgs[1].(MsgZoneLink)
+	if !ok {
+		t.Fatalf("got %T, want MsgZoneLink", msgs[1])
+	}
+
+	if evt.URL != url {
+		t.Errorf("got url %q, want %q", evt.URL, url)
+	}
+
+	// Only left clicks should be considered link clicks.
+	if msgs = DefaultManager.zoneMsgs(tea.MouseReleaseMsg{X: 5, Y: 0, Button: tea.MouseRight}); len(msgs) != 1 {
+		t.Errorf("got %d messages, want 1", len(msgs))
+	}
+}
@@ -6,10 +6,14 @@ package zone
 
 import (
 	"context"
+	"fmt"
+	"log/slog"
 	"strconv"
 	"sync"
 	"sync/atomic"
 	"time"
+
+	tea "charm.land/bubbletea/v2"
 )
 
 const (
@@ -37,18 +41,71 @@ var (
 // later retrieval/bounds checks.
 //
 // The zone manager is enabled by default, and can be toggled by calling
-// SetEnabled().
-func New() (m *Manager) {
+// SetEnabled(). See Option for the available options, New() panics if any of
+// them are invalid. Call Close() once the manager is no longer needed, to stop
+// its worker.
+func New(opts ...Option) *Manager {
+	return NewWithContext(context.Background(), opts...)
+}
+
+// NewWithContext is the same as New(), however the manager is closed (see
+// Close()) once ctx is done, e.g. when the program using it exits.
+func NewWithContext(ctx context.Context, opts ...Option) (m *Manager) {
+	config := &managerConfig{
+		bufferSize: DefaultBufferSize,
+		width:      printableRuneWidth,
+		markerEnd:  identEnd,
+		enabled:    true,
+	}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	if config.markerEnd < 'p' || config.markerEnd > '~' {
+		panic(fmt.Sprintf("zone: marker terminator %q must be in the range 'p' to '~' (see WithMarkerTerminator())", config.markerEnd))
+	}
+
 	m = &Manager{
-		setChan: make(chan *ZoneInfo, 200),
-		zones:   make(map[string]*ZoneInfo),
-		ids:     make(map[string]string),
-		rids:    make(map[string]string),
+		logger:     config.logger,
+		hooks:      config.hooks,
+		sync:       config.sync,
+		width:      config.width,
+		markerEnd:  rune(config.markerEnd),
+		evictAfter: int64(config.evictAfter),
+		setChan:    make(chan *zoneCommit, config.bufferSize),
+		zones:      make(map[string]*ZoneInfo),
+		ids:        make(map[string]string),
+		rids:       make(map[string]string),
+		meta:       make(map[string]*zoneMeta),
+		used:       make(map[string]int64),
+		tooltip: tooltipState{
+			delay: DefaultTooltipDelay,
+			style: DefaultTooltipStyle,
+		},
+		menu: menuState{
+			styles: DefaultMenuStyles,
+			items:  make(map[string][]MenuItem),
+		},
+		selection: selectionState{
+			style: DefaultSelectionStyle,
+		},
+		inspector: inspectorState{
+			styles: DefaultInspectorStyles,
+		},
 	}
 
-	m.ctx, m.cancel = context.WithCancel(context.Background())
-	m.enabled.Store(true)
-	go m.zoneWorker()
+	m.menu.manager = m
+	m.menu.prefix = m.NewPrefix() + "menu_"
+
+	m.ctx, m.cancel = context.WithCancel(ctx)
+	m.enabled.Store(config.enabled)
+
+	// Drop the zones once closed, including when ctx is done.
+	context.AfterFunc(m.ctx, m.clearZones)
+
+	if !m.sync {
+		go m.zoneWorker()
+	}
 
 	return m
 }
@@ -60,14 +117,47 @@ type Manager struct {
 	cancel  func()
 	enabled atomic.Bool
 
-	setChan chan *ZoneInfo
+	sync       bool      // Store zones synchronously, see WithSyncCommit().
+	width      WidthFunc // See WithWidthFunc().
+	markerEnd  rune      // Final byte of zone markers, see WithMarkerTerminator().
+	evictAfter int64     // See WithRegistryEviction().
+	scans      int64     // Number of scans, used for eviction. Protected by atomic operations.
+
+	setChan chan *zoneCommit
+
+	diagMu      sync.Mutex
+	diagnostics []Diagnostic // Diagnostics of the last scanned view.
+	logger      *slog.Logger
+
+	hooks Hooks
 
 	zoneMu sync.RWMutex
 	zones  map[string]*ZoneInfo
 
 	idMu sync.RWMutex
-	ids  map[string]string // user ID -> generated control sequence ID.
-	rids map[string]string // generated control sequence ID -> user ID.
+	ids  map[string]string    // user ID -> generated control sequence ID.
+	rids map[string]string    // generated control sequence ID -> user ID.
+	meta map[string]*zoneMeta // user ID -> additional zone information.
+	used map[string]int64     // user ID -> last scan the ID was used, only if evicting.
+
+	captureMu    sync.RWMutex
+	captured     string // user ID of the zone which has the mouse captured.
+	autoCaptured string // user ID of the zone captured by a wrapped model, if any.
+
+	pointerMu sync.Mutex
+	pointer   PointerShape // The last pointer shape sent to the terminal.
+
+	tooltipMu sync.Mutex
+	tooltip   tooltipState
+
+	menuMu sync.Mutex
+	menu   menuState
+
+	selectionMu sync.Mutex
+	selection   selectionState
+
+	inspectorMu sync.Mutex
+	inspector   inspectorState
 }
 
 func (m *Manager) checkInitialized() {
@@ -76,9 +166,68 @@ func (m *Manager) checkInitialized() {
 	}
 }
 
-// Close stops the manager worker.
+// Close stops the manager worker, and drops all stored zones. Calling Close()
+// more than once has no effect.
+//
+// Once closed, the manager behaves as if it was disabled (see SetEnabled()),
+// and can't be enabled again: Mark() returns its input unchanged, Scan() only
+// strips zone markers, Get() returns nil, Flush() returns immediately, and mouse
+// events don't match any zones. None of the methods block or panic.
 func (m *Manager) Close() {
 	m.cancel()
+	m.clearZones()
+}
+
+// Closed returns true if the manager has been closed, either with Close(), or
+// because the context provided to NewWithContext() is done.
+func (m *Manager) Closed() bool {
+	return m.ctx.Err() != nil
+}
+
+// Reset drops all stored zones and registered IDs (see Mark()), along with any
+// state tied to them: the zone with the mouse captured, context menus, tooltips,
+// the text selection and diagnostics. Options and styles are kept. Markers
+// rendered before calling Reset() are unknown afterwards, so views should be
+// rendered again, rather than cached.
+//
+// This is useful to reclaim memory from IDs which are no longer used (see also
+// WithRegistryEviction()), or when switching between unrelated screens.
+func (m *Manager) Reset() {
+	m.idMu.Lock()
+	m.ids = make(map[string]string)
+	m.rids = make(map[string]string)
+	m.meta = make(map[string]*zoneMeta)
+	m.used = make(map[string]int64)
+	m.idMu.Unlock()
+
+	// Queue the reset after any views which haven't been stored yet, but also
+	// clear immediately.
+	m.commit(&zoneCommit{frame: true})
+	m.clearZones()
+
+	m.Release()
+	m.ClearSelection()
+
+	m.menuMu.Lock()
+	m.menu.close()
+	m.menu.items = make(map[string][]MenuItem)
+	m.menuMu.Unlock()
+
+	m.tooltipMu.Lock()
+	m.tooltip.id = ""
+	m.tooltip.visible = false
+	m.tooltipMu.Unlock()
+
+	m.diagMu.Lock()
+	m.diagnostics = nil
+	m.diagMu.Unlock()
+}
+
+// clearZones drops all stored zones.
+func (m *Manager) clearZones() {
+	m.zoneMu.Lock()
+	m.zones = make(map[string]*ZoneInfo)
+	m.zoneMu.Unlock()
 }
 
 // SetEnabled enables or disables the zone manager. When disabled, the zone manager
@@ -91,18 +240,46 @@ func (m *Manager) SetEnabled(enabled bool) {
 
 	if !enabled {
 		// Tell the worker to clear all zones if we're disabling the manager.
-		iteration := time.Now().Nanosecond()
-		m.setChan <- &ZoneInfo{iteration: iteration}
+		m.commit(&zoneCommit{frame: true})
+	}
+}
+
+// SetEnabledCmd is the same as SetEnabled(), however it also returns a command
+// which sends a MsgZoneEnabledChanged if the enabled state changed, so
+// components which render differently depending on whether the manager is
+// enabled can update. If the state didn't change, nil is returned.
+//
+// Usage example:
+//
+//	case tea.KeyPressMsg:
+//		if msg.String() == "ctrl+e" {
+//			return m, zone.SetEnabledCmd(!zone.Enabled())
+//		}
+func (m *Manager) SetEnabledCmd(enabled bool) tea.Cmd {
+	previous := m.Enabled()
+	m.SetEnabled(enabled)
+
+	if current := m.Enabled(); current != previous {
+		return func() tea.Msg { return MsgZoneEnabledChanged{Enabled: current} }
 	}
+	return nil
 }
 
 // Enabled returns whether the zone manager is enabled or not. When disabled,
 // the zone manager will still parse zone information, however it will immediately
 // drop it and remove zone markers from the resulting output.
 //
-// The zone manager is enabled by default.
+// The zone manager is enabled by default. A closed manager is always disabled
+// (see Close()).
 func (m *Manager) Enabled() bool {
-	return m.enabled.Load()
+	return m.enabled.Load() && !m.Closed()
+}
+
+// Width returns the printable cell width of s, measured with the width function
+// used to calculate the position of zones (see WithWidthFunc()). Zone markers
+// and other ANSI sequences aren't counted.
+func (m *Manager) Width(s string) int {
+	return m.width(s)
 }
 
 // NewPrefix generates a zone marker ID prefix, which can help prevent overlapping
@@ -152,6 +329,14 @@ func (m *Manager) NewPrefix() string {
 //
 // When the zone manager is disabled, Mark() will return v without any changes.
 func (m *Manager) Mark(id, v string) string {
+	return m.MarkWith(id, v)
+}
+
+// MarkWith is the same as Mark(), however it also allows attaching additional
+// information to the zone, like a payload (see WithPayload()). The information
+// is replaced on every call to Mark() or MarkWith() with the same ID, so it must
+// be provided on every render.
+func (m *Manager) MarkWith(id, v string, opts ...MarkOption) string {
 	if !m.Enabled() {
 		return v
 	}
@@ -160,23 +345,63 @@ func (m *Manager) Mark(id, v string) string {
 		return v
 	}
 
+	var meta *zoneMeta
+	if len(opts) > 0 {
+		meta = &zoneMeta{}
+		for _, opt := range opts {
+			opt(meta)
+		}
+	}
+
 	m.idMu.RLock()
 	gid := m.ids[id]
+	_, hasMeta := m.meta[id]
 	m.idMu.RUnlock()
 
-	if gid != "" {
+	if gid != "" && meta == nil && !hasMeta {
 		return gid + v + gid
 	}
 
 	m.idMu.Lock()
-	gid = string(identStart) + string(identBracket) + strconv.FormatInt(atomic.AddInt64(&markerCounter, 1), 10) + string(identEnd)
-	m.ids[id] = gid
-	m.rids[gid] = id
+	if gid == "" {
+		gid = string(identStart) + string(identBracket) + strconv.FormatInt(atomic.AddInt64(&markerCounter, 1), 10) + string(m.markerEnd)
+		m.ids[id] = gid
+		m.rids[gid] = id
+
+		if m.evictAfter > 0 {
+			m.used[id] = atomic.LoadInt64(&m.scans)
+		}
+	}
+
+	if meta != nil {
+		m.meta[id] = meta
+	} else {
+		delete(m.meta, id)
+	}
 	m.idMu.Unlock()
 
 	return gid + v + gid
 }
 
+// MarkFunc is the same as Mark(), however it also registers fn as the handler
+// for the zone (see WithHandler()). This allows zones like buttons to be entirely
+// self-contained, without the parent model having to check the ID of each zone
+// in Update().
+//
+// Usage example:
+//
+//	func (m model) View() string {
+//		return zone.MarkFunc("confirm", button.Render("OK"), func(evt zone.ZoneEvent) tea.Cmd {
+//			if _, ok := evt.Event.(tea.MouseReleaseMsg); ok {
+//				return confirmCmd
+//			}
+//			return nil
+//		})
+//	}
+func (m *Manager) MarkFunc(id, v string, fn HandlerFunc) string {
+	return m.MarkWith(id, v, WithHandler(fn))
+}
+
 // Clear removes any stored zones for the given ID.
 func (m *Manager) Clear(id string) {
 	m.zoneMu.Lock()
@@ -194,12 +419,24 @@ func (m *Manager) Get(id string) (zone *ZoneInfo) {
 }
 
 // getReverse returns the component ID from a generated ID (that includes ANSI
-// escape codes).
-func (m *Manager) getReverse(id string) (resolved string) {
+// escape codes), and any additional information attached to the zone.
+func (m *Manager) getReverse(id string) (resolved string, meta *zoneMeta) {
 	m.idMu.RLock()
 	resolved = m.rids[id]
+	meta = m.meta[resolved]
 	m.idMu.RUnlock()
-	return resolved
+	return resolved, meta
+}
+
+// zoneCommit is a batch of changes to the stored zones, sent to the worker.
+type zoneCommit struct {
+	// frame is true if the stored zones should be replaced with zones (e.g. the
+	// zones of a scanned view). Otherwise, the commit is only a flush request.
+	frame bool
+	zones []*ZoneInfo
+
+	// flush are closed once the commit is stored, see Manager.Flush().
+	flush []chan struct{}
 }
 
 func (m *Manager) zoneWorker() {
@@ -207,20 +444,117 @@ func (m *Manager) zoneWorker() {
 		select {
 		case <-m.ctx.Done():
 			return
-		case xy := <-m.setChan:
-			m.zoneMu.Lock()
-			if xy.id != "" {
-				m.zones[m.getReverse(xy.id)] = xy
-			} else {
-				// Assume previous iterations are cleared.
-				for k := range m.zones {
-					if m.zones[k].iteration != xy.iteration {
-						delete(m.zones, k)
-					}
-				}
-			}
-			m.zoneMu.Unlock()
+		case c := <-m.setChan:
+			m.store(c)
+		}
+	}
+}
+
+// commit sends c to the worker to be stored, or stores it immediately if using
+// WithSyncCommit(). commit never blocks: if the worker has fallen behind and the
+// queue is full, the oldest queued commit is dropped, as only the zones of the
+// latest view matter. If the manager is closed, c is dropped.
+func (m *Manager) commit(c *zoneCommit) {
+	if m.sync {
+		m.store(c)
+		return
+	}
+
+	for m.ctx.Err() == nil {
+		select {
+		case m.setChan <- c:
+			return
+		default:
+		}
+
+		select {
+		case old := <-m.setChan:
+			// Acknowledge flush requests of the dropped commit once c is stored,
+			// which is after the dropped commit would have been.
+			c.flush = append(old.flush, c.flush...)
+		default:
+		}
+	}
+}
+
+// store stores the zones of c, replacing all existing zones if c is a frame,
+// and acknowledges any flush requests.
+func (m *Manager) store(c *zoneCommit) {
+	if c.frame {
+		zones := make(map[string]*ZoneInfo, len(c.zones))
+		for _, zone := range c.zones {
+			zones[zone.name] = zone
+		}
+
+		m.zoneMu.Lock()
+		// Don't store zones which were queued before the manager was closed.
+		if !m.Closed() {
+			m.zones = zones
+		}
+		m.zoneMu.Unlock()
+	}
+
+	for _, done := range c.flush {
+		close(done)
+	}
+}
+
+// evict removes IDs from the registry which haven't been used recently, if
+// enabled with WithRegistryEviction(). zones are the zones of the last scanned
+// view, which are marked as used.
+func (m *Manager) evict(zones []*ZoneInfo) {
+	if m.evictAfter <= 0 {
+		return
+	}
+
+	scan := atomic.AddInt64(&m.scans, 1)
+
+	m.idMu.Lock()
+	defer m.idMu.Unlock()
+
+	for _, zone := range zones {
+		m.used[zone.name] = scan
+	}
+
+	// Only sweep periodically, as it has to check every ID.
+	if scan%m.evictAfter != 0 {
+		return
+	}
+
+	for id, last := range m.used {
+		if scan-last < m.evictAfter {
+			continue
 		}
+
+		delete(m.rids, m.ids[id])
+		delete(m.ids, id)
+		delete(m.meta, id)
+		delete(m.used, id)
+	}
+}
+
+// Flush blocks until the zones of all previous calls to Scan() have been stored,
+// so that Get() returns the zones of the last scanned view. This is mainly
+// useful in tests, where mouse events are sent immediately after rendering. If
+// the manager is closed, or using WithSyncCommit(), Flush() returns immediately.
+func (m *Manager) Flush() {
+	if m.sync {
+		return
+	}
+
+	done := make(chan struct{})
+
+	// Flush requests may block, as they can't be dropped without losing the
+	// frames queued before them.
+	select {
+	case m.setChan <- &zoneCommit{flush: []chan struct{}{done}}:
+	case <-m.ctx.Done():
+		return
+	}
+
+	select {
+	case <-done:
+	case <-m.ctx.Done():
 	}
 }
 
@@ -229,20 +563,48 @@ func (m *Manager) zoneWorker() {
 // by the outer most model/component of your application, and not inside of a
 // model/component child.
 //
-// Scan buffers the zone info to be stored, so an immediate call to Get(id) may
-// not return the correct information. Thus it's recommended to primarily use
+// Scan buffers the zone info to be stored by a background worker, without ever
+// blocking on it, so an immediate call to Get(id) may not return the correct
+// information. Thus it's recommended to primarily use
 // Get(id) for actions like mouse events, which don't occur immediately after a
-// view shift (where the previously stored zone info might be different).
+// view shift (where the previously stored zone info might be different). Use
+// WithSyncCommit() to store zones before Scan() returns instead.
 //
 // When the zone manager is disabled (via SetEnabled(false)), Scan() will return
 // the original view output with all zone markers stripped. It will still parse
 // the input for zone markers, as some users may cache generated views. In most
 // situations when the zone manager is disabled (and thus Mark() returns input
 // unchanged), Scan() will not need to do any work.
+//
+// When the zone inspector is enabled (see SetInspector()), the bounds of each
+// zone are painted on the returned view.
 func (m *Manager) Scan(v string) string {
-	iteration := time.Now().Nanosecond()
-	s := newScanner(m, v, iteration)
+	observed := m.observed()
+
+	var start time.Time
+	if observed {
+		start = time.Now()
+	}
+
+	s := newScanner(m, v)
 	s.run()
-	m.setChan <- &ZoneInfo{iteration: iteration}
-	return s.input
+
+	zones := append(s.zones, m.menuZones(s.zones)...)
+
+	m.commit(&zoneCommit{frame: true, zones: zones})
+	m.evict(zones)
+
+	m.setFrame(s.input)
+	m.setDiagnostics(s.diagnostics)
+
+	if observed {
+		m.reportScan(ScanInfo{
+			Duration: time.Since(start),
+			Zones:    len(zones),
+			Registry: m.registrySize(),
+			Orphaned: len(s.tracked),
+			Dropped:  s.dropped,
+		})
+	}
+	return m.inspect(s.input, zones)
 }
@@ -4,9 +4,16 @@
 
 package zone
 
-import tea "charm.land/bubbletea/v2"
+import (
+	"log/slog"
+	"time"
 
-// DefaultManager is an app-wide manager. To initialize it, call NewGlobal().
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+)
+
+// DefaultManager is an app-wide manager. To initialize it, call NewGlobal(), or
+// SetDefault() to use an existing manager.
 var DefaultManager *Manager
 
 // NewGlobal initializes a global manager, so you don't have to pass the manager
@@ -15,29 +22,62 @@ var DefaultManager *Manager
 // make sure you allow users to pass in their own manager.
 //
 // The zone manager is enabled by default, and can be toggled by calling
-// SetEnabled().
-func NewGlobal() {
-	if DefaultManager != nil {
+// SetEnabled(). See Option for the available options. If the global manager is
+// already initialized, NewGlobal() does nothing, unless it has been closed, in
+// which case a new manager replaces it.
+func NewGlobal(opts ...Option) {
+	if DefaultManager != nil && !DefaultManager.Closed() {
 		return
 	}
 
-	DefaultManager = New()
+	DefaultManager = New(opts...)
+}
+
+// SetDefault replaces the global manager with m, e.g. a manager created with
+// NewWithContext(). The previous manager isn't closed. SetDefault() isn't safe
+// to call concurrently with other global functions, so it should be called
+// before the program starts.
+func SetDefault(m *Manager) {
+	DefaultManager = m
 }
 
-// Close stops the manager worker.
+// Close stops the manager worker, and drops all stored zones. See
+// [Manager.Close] for more information.
 func Close() {
 	DefaultManager.checkInitialized()
 	DefaultManager.Close()
 }
 
+// Closed returns true if the manager has been closed.
+func Closed() bool {
+	DefaultManager.checkInitialized()
+	return DefaultManager.Closed()
+}
+
+// Reset drops all stored zones and registered IDs, along with any state tied to
+// them. See [Manager.Reset] for more information.
+func Reset() {
+	DefaultManager.checkInitialized()
+	DefaultManager.Reset()
+}
+
 // SetEnabled enables or disables the zone manager. When disabled, the zone manager
 // will still parse zone information, however it will immediately drop it and remove
 // zone markers from the resulting output.
 //
-// The zone manager is enabled by default.
+// The zone manager is enabled by default. See [Manager.SetEnabled] for more
+// information.
 func SetEnabled(v bool) {
 	DefaultManager.checkInitialized()
-	DefaultManager.enabled.Store(v)
+	DefaultManager.SetEnabled(v)
+}
+
+// SetEnabledCmd is the same as SetEnabled(), however it also returns a command
+// which sends a MsgZoneEnabledChanged if the enabled state changed. See
+// [Manager.SetEnabledCmd] for more information.
+func SetEnabledCmd(v bool) tea.Cmd {
+	DefaultManager.checkInitialized()
+	return DefaultManager.SetEnabledCmd(v)
 }
 
 // Enabled returns whether the zone manager is enabled or not. When disabled,
@@ -47,7 +87,15 @@ func SetEnabled(v bool) {
 // The zone manager is enabled by default.
 func Enabled() bool {
 	DefaultManager.checkInitialized()
-	return DefaultManager.enabled.Load()
+	return DefaultManager.Enabled()
+}
+
+// Width returns the printable cell width of s, measured with the width function
+// used to calculate the position of zones. See [Manager.Width] for more
+// information.
+func Width(s string) int {
+	DefaultManager.checkInitialized()
+	return DefaultManager.Width(s)
 }
 
 // NewPrefix generates a zone marker ID prefix, which can help prevent overlapping
@@ -102,6 +150,30 @@ func Mark(id, v string) string {
 	return DefaultManager.Mark(id, v)
 }
 
+// MarkWith is the same as Mark(), however it also allows attaching additional
+// information to the zone, like a payload (see WithPayload()). The information
+// is replaced on every call to Mark() or MarkWith() with the same ID, so it must
+// be provided on every render.
+func MarkWith(id, v string, opts ...MarkOption) string {
+	DefaultManager.checkInitialized()
+	return DefaultManager.MarkWith(id, v, opts...)
+}
+
+// MarkFunc is the same as Mark(), however it also registers fn as the handler
+// for the zone (see WithHandler()). See [Manager.MarkFunc] for more information.
+func MarkFunc(id, v string, fn HandlerFunc) string {
+	DefaultManager.checkInitialized()
+	return DefaultManager.MarkFunc(id, v, fn)
+}
+
+// MarkLink returns text wrapped in an OSC 8 hyperlink pointing to url, which
+// is also marked as a zone with the provided ID. See [Manager.MarkLink] for more
+// information.
+func MarkLink(id, url, text string, opts ...MarkOption) string {
+	DefaultManager.checkInitialized()
+	return DefaultManager.MarkLink(id, url, text, opts...)
+}
+
 // Clear removes any stored zones for the given ID.
 func Clear(id string) {
 	DefaultManager.checkInitialized()
@@ -135,6 +207,14 @@ func Scan(v string) string {
 	return DefaultManager.Scan(v)
 }
 
+// Flush blocks until the zones of all previous calls to Scan() have been stored,
+// so that Get() returns the zones of the last scanned view. See [Manager.Flush]
+// for more information.
+func Flush() {
+	DefaultManager.checkInitialized()
+	DefaultManager.Flush()
+}
+
 // AnyInBounds sends a MsgZoneInBounds message to the provided model for each zone
 // that is in the bounds of the provided mouse event. The results of the call to
 // Update() are discarded.
@@ -154,3 +234,265 @@ func AnyInBoundsAndUpdate(model tea.Model, mouse tea.MouseMsg) (tea.Model, tea.C
 	DefaultManager.checkInitialized()
 	return DefaultManager.AnyInBoundsAndUpdate(model, mouse)
 }
+
+// Wrap returns model wrapped in a tea.Model which automatically handles zone
+// scanning and dispatching, which means you don't have to call Scan() yourself.
+// See [Manager.Wrap] for more information.
+func Wrap(model tea.Model, opts ...WrapOption) tea.Model {
+	DefaultManager.checkInitialized()
+	return DefaultManager.Wrap(model, opts...)
+}
+
+// ScanView is the same as Scan(), however it scans the content of the provided
+// view, returning the view with the zone markers stripped. If the view has no
+// mouse mode set, cell motion tracking will be enabled.
+func ScanView(view tea.View) tea.View {
+	DefaultManager.checkInitialized()
+	return DefaultManager.ScanView(view)
+}
+
+// Dispatch invokes the handler of each zone that is in the bounds of the
+// provided mouse event (see WithHandler() and MarkFunc()), in alphabetical sorted
+// order of the ID. The resulting commands are wrapped in tea.Batch().
+func Dispatch(mouse tea.MouseMsg) tea.Cmd {
+	DefaultManager.checkInitialized()
+	return DefaultManager.Dispatch(mouse)
+}
+
+// RouteScroll returns a MsgZoneScroll for the innermost scrollable zone (see
+// WithScrollable()) under the provided mouse wheel event. If the innermost zone
+// is at its scroll limit, the event is routed to the next scrollable parent zone.
+// If no zone is able to scroll, ok will be false.
+func RouteScroll(mouse tea.MouseWheelMsg) (msg MsgZoneScroll, ok bool) {
+	DefaultManager.checkInitialized()
+	return DefaultManager.RouteScroll(mouse)
+}
+
+// Capture captures the mouse for the zone with the provided ID, routing all
+// mouse events to it (regardless of the bounds of the zone) until Release() is
+// called. See [Manager.Capture] for more information.
+func Capture(id string) {
+	DefaultManager.checkInitialized()
+	DefaultManager.Capture(id)
+}
+
+// Release releases the mouse capture started with Capture(), if any.
+func Release() {
+	DefaultManager.checkInitialized()
+	DefaultManager.Release()
+}
+
+// Captured returns the ID of the zone which currently has the mouse captured,
+// or an empty string if no zone has the mouse captured.
+func Captured() string {
+	DefaultManager.checkInitialized()
+	return DefaultManager.Captured()
+}
+
+// UpdatePointer returns a command which updates the shape of the mouse pointer
+// to match the zone under the provided mouse event (see WithPointer()), or nil if
+// the shape doesn't need to change. See [Manager.UpdatePointer] for more
+// information.
+func UpdatePointer(msg tea.Msg) tea.Cmd {
+	DefaultManager.checkInitialized()
+	return DefaultManager.UpdatePointer(msg)
+}
+
+// ResetPointer returns a command which restores the default shape of the mouse
+// pointer, or nil if it wasn't changed. See [Manager.ResetPointer] for more
+// information.
+func ResetPointer() tea.Cmd {
+	DefaultManager.checkInitialized()
+	return DefaultManager.ResetPointer()
+}
+
+// Quit returns a command which restores the default shape of the mouse pointer,
+// and then quits the program. See [Manager.Quit] for more information.
+func Quit() tea.Cmd {
+	DefaultManager.checkInitialized()
+	return DefaultManager.Quit()
+}
+
+// SetTooltipDelay sets how long the mouse has to hover over a zone before its
+// tooltip is shown. Defaults to DefaultTooltipDelay. If d is 0, tooltips are
+// shown immediately.
+func SetTooltipDelay(d time.Duration) {
+	DefaultManager.checkInitialized()
+	DefaultManager.SetTooltipDelay(d)
+}
+
+// SetTooltipStyle sets the style used to render tooltips. Defaults to
+// DefaultTooltipStyle.
+func SetTooltipStyle(style lipgloss.Style) {
+	DefaultManager.checkInitialized()
+	DefaultManager.SetTooltipStyle(style)
+}
+
+// UpdateTooltip tracks which zone the mouse is hovering over, returning a
+// command which fires once the tooltip delay has passed. See
+// [Manager.UpdateTooltip] for more information.
+func UpdateTooltip(msg tea.Msg) tea.Cmd {
+	DefaultManager.checkInitialized()
+	return DefaultManager.UpdateTooltip(msg)
+}
+
+// Tooltip returns the tooltip of the zone the mouse is hovering over, rendered
+// and positioned next to the zone, if the tooltip delay has passed. See
+// [Manager.Tooltip] for more information.
+func Tooltip(width, height int) (overlay Overlay, ok bool) {
+	DefaultManager.checkInitialized()
+	return DefaultManager.Tooltip(width, height)
+}
+
+// SetMenu registers a context menu for the zone with the provided ID, which is
+// opened at the mouse position when the right mouse button is pressed within the
+// zone. See [Manager.SetMenu] for more information.
+func SetMenu(id string, items ...MenuItem) {
+	DefaultManager.checkInitialized()
+	DefaultManager.SetMenu(id, items...)
+}
+
+// SetMenuStyles sets the styles used to render context menus. Defaults to
+// DefaultMenuStyles.
+func SetMenuStyles(styles MenuStyles) {
+	DefaultManager.checkInitialized()
+	DefaultManager.SetMenuStyles(styles)
+}
+
+// MenuItemID returns the zone ID of a menu item, while its menu is open. See
+// [Manager.MenuItemID] for more information.
+func MenuItemID(item MenuItem) string {
+	DefaultManager.checkInitialized()
+	return DefaultManager.MenuItemID(item)
+}
+
+// MenuOpen returns true if a context menu is currently open.
+func MenuOpen() bool {
+	DefaultManager.checkInitialized()
+	return DefaultManager.MenuOpen()
+}
+
+// CloseMenu closes the context menu, if one is open.
+func CloseMenu() {
+	DefaultManager.checkInitialized()
+	DefaultManager.CloseMenu()
+}
+
+// Menu returns the open context menu, rendered and positioned at the mouse
+// position it was opened at. See [Manager.Menu] for more information.
+func Menu() (overlay Overlay, ok bool) {
+	DefaultManager.checkInitialized()
+	return DefaultManager.Menu()
+}
+
+// UpdateMenu opens, closes and selects items of context menus, based on the
+// provided message. See [Manager.UpdateMenu] for more information.
+func UpdateMenu(msg tea.Msg) (cmd tea.Cmd, handled bool) {
+	DefaultManager.checkInitialized()
+	return DefaultManager.UpdateMenu(msg)
+}
+
+// SetSelectionStyle sets the style used to highlight selected text. Defaults to
+// DefaultSelectionStyle.
+func SetSelectionStyle(style lipgloss.Style) {
+	DefaultManager.checkInitialized()
+	DefaultManager.SetSelectionStyle(style)
+}
+
+// UpdateSelection tracks text selection using the mouse, across the whole
+// scanned view. See [Manager.UpdateSelection] for more information.
+func UpdateSelection(msg tea.Msg) tea.Cmd {
+	DefaultManager.checkInitialized()
+	return DefaultManager.UpdateSelection(msg)
+}
+
+// Selection returns the currently selected text, without styling. See
+// [Manager.Selection] for more information.
+func Selection() (text string, ok bool) {
+	DefaultManager.checkInitialized()
+	return DefaultManager.Selection()
+}
+
+// ClearSelection clears the current selection, if any.
+func ClearSelection() {
+	DefaultManager.checkInitialized()
+	DefaultManager.ClearSelection()
+}
+
+// CopySelection returns a command which copies the currently selected text to
+// the system clipboard, using OSC 52. See [Manager.CopySelection] for more
+// information.
+func CopySelection() tea.Cmd {
+	DefaultManager.checkInitialized()
+	return DefaultManager.CopySelection()
+}
+
+// HighlightSelection returns view with the selected text highlighted. See
+// [Manager.HighlightSelection] for more information.
+func HighlightSelection(view string) string {
+	DefaultManager.checkInitialized()
+	return DefaultManager.HighlightSelection(view)
+}
+
+// Zones returns all zones of the last scanned view, sorted by ID.
+func Zones() []*ZoneInfo {
+	DefaultManager.checkInitialized()
+	return DefaultManager.Zones()
+}
+
+// Annotate returns a plain text image of view annotated with the zones of the
+// last scanned view. See [Manager.Annotate] for more information.
+func Annotate(view string) string {
+	DefaultManager.checkInitialized()
+	return DefaultManager.Annotate(view)
+}
+
+// SetInspector enables or disables the zone inspector, which paints the bounds
+// of zones on scanned views. See [Manager.SetInspector] for more information.
+func SetInspector(enabled bool) {
+	DefaultManager.checkInitialized()
+	DefaultManager.SetInspector(enabled)
+}
+
+// Inspecting returns true if the zone inspector is enabled.
+func Inspecting() bool {
+	DefaultManager.checkInitialized()
+	return DefaultManager.Inspecting()
+}
+
+// SetInspectorStyles sets the styles used by the zone inspector. Defaults to
+// DefaultInspectorStyles.
+func SetInspectorStyles(styles InspectorStyles) {
+	DefaultManager.checkInitialized()
+	DefaultManager.SetInspectorStyles(styles)
+}
+
+// UpdateInspector tracks the position of the mouse, which the zone inspector
+// uses to highlight the zone under the mouse. See [Manager.UpdateInspector] for
+// more information.
+func UpdateInspector(msg tea.Msg) {
+	DefaultManager.checkInitialized()
+	DefaultManager.UpdateInspector(msg)
+}
+
+// Diagnostics returns the problems found while scanning the last view, like
+// duplicate IDs or unbalanced markers. See [Manager.Diagnostics] for more
+// information.
+func Diagnostics() []Diagnostic {
+	DefaultManager.checkInitialized()
+	return DefaultManager.Diagnostics()
+}
+
+// SetLogger sets the logger used to report diagnostics as warnings. See
+// [Manager.SetLogger] for more information.
+func SetLogger(logger *slog.Logger) {
+	DefaultManager.checkInitialized()
+	DefaultManager.SetLogger(logger)
+}
+
+// Scope returns a new scoped view of the manager, which prefixes all IDs with a
+// unique prefix. See [Scoped] for more information.
+func Scope() Scoped {
+	DefaultManager.checkInitialized()
+	return DefaultManager.Scope()
+}
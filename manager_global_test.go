@@ -0,0 +1,436 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package zone
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+)
+
+// pick calls g if global is true, otherwise i.
+func pick[T any](global bool, g, i func() T) T {
+	if global {
+		return g()
+	}
+	return i()
+}
+
+// do calls g if global is true, otherwise i.
+func do(global bool, g, i func()) {
+	if global {
+		g()
+		return
+	}
+	i()
+}
+
+type parityModel struct {
+	ids []string
+}
+
+func (p *parityModel) Init() tea.Cmd {
+	return nil
+}
+
+func (p *parityModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(MsgZoneInBounds); ok {
+		p.ids = append(p.ids, msg.Zone.ID())
+	}
+	return p, nil
+}
+
+func (p *parityModel) View() tea.View {
+	return tea.NewView("")
+}
+
+// bounds summarizes a zone, so zones of different managers can be compared.
+func bounds(z *ZoneInfo) string {
+	if z.IsZero() {
+		return "<nil>"
+	}
+	return fmt.Sprintf("%s (%d,%d)-(%d,%d) %v", z.ID(), z.StartX, z.StartY, z.EndX, z.EndY, z.Payload())
+}
+
+// msgOf returns the message of cmd, or nil.
+func msgOf(cmd tea.Cmd) tea.Msg {
+	if cmd == nil {
+		return nil
+	}
+	return cmd()
+}
+
+// newParityManager returns a manager which has scanned a view with a few zones.
+func newParityManager() *Manager {
+	m := New(WithSyncCommit(true))
+	m.SetMenu("a", MenuItem{ID: "copy", Label: "Copy"})
+	m.SetTooltipDelay(0)
+
+	_ = m.Scan("title\n" + m.MarkWith(
+		"a", "[ab]",
+		WithPayload(1),
+		WithPointer(PointerHand),
+		WithTooltip("tip"),
+		WithScrollable(nil),
+	) + " " + m.MarkFunc("b", "cd", func(evt ZoneEvent) tea.Cmd {
+		return func() tea.Msg { return "handled " + evt.ID }
+	}))
+
+	return m
+}
+
+var (
+	parityClick   = tea.MouseClickMsg{X: 1, Y: 1, Button: tea.MouseLeft}
+	parityRelease = tea.MouseReleaseMsg{X: 1, Y: 1, Button: tea.MouseLeft}
+	parityMotion  = tea.MouseMotionMsg{X: 1, Y: 1}
+	parityRight   = tea.MouseClickMsg{X: 1, Y: 1, Button: tea.MouseRight}
+	parityWheel   = tea.MouseWheelMsg{X: 1, Y: 1, Button: tea.MouseWheelDown}
+	parityHandler = tea.MouseClickMsg{X: 5, Y: 1, Button: tea.MouseLeft}
+)
+
+// parityCases calls each global function (if global is true) or the matching
+// manager method, returning a summary of the results and the resulting state
+// of the manager, which must be the same either way.
+var parityCases = map[string]func(m *Manager, global bool) any{
+	"Close": func(m *Manager, global bool) any {
+		do(global, Close, m.Close)
+		return []any{m.Closed(), bounds(m.Get("a"))}
+	},
+	"Closed": func(m *Manager, global bool) any {
+		m.Close()
+		return pick(global, Closed, m.Closed)
+	},
+	"Reset": func(m *Manager, global bool) any {
+		do(global, Reset, m.Reset)
+		return []any{m.registrySize(), bounds(m.Get("a"))}
+	},
+	"SetEnabled": func(m *Manager, global bool) any {
+		do(global, func() { SetEnabled(false) }, func() { m.SetEnabled(false) })
+		return []any{m.Enabled(), bounds(m.Get("a"))}
+	},
+	"SetEnabledCmd": func(m *Manager, global bool) any {
+		cmd := pick(global, func() tea.Cmd { return SetEnabledCmd(false) }, func() tea.Cmd { return m.SetEnabledCmd(false) })
+		return []any{msgOf(cmd), m.Enabled(), bounds(m.Get("a"))}
+	},
+	"Enabled": func(m *Manager, global bool) any {
+		m.Close()
+		return pick(global, Enabled, m.Enabled)
+	},
+	"Width": func(m *Manager, global bool) any {
+		return pick(global, func() int { return Width("a\x1b[1mb\x1b[m" + m.Mark("x", "c")) }, func() int { return m.Width("a\x1b[1mb\x1b[m" + m.Mark("x", "c")) })
+	},
+	"NewPrefix": func(m *Manager, global bool) any {
+		prefix := pick(global, NewPrefix, m.NewPrefix)
+		return len(prefix) > len("zone___") && prefix[:5] == "zone_"
+	},
+	"Mark": func(m *Manager, global bool) any {
+		v := pick(global, func() string { return Mark("c", "x") }, func() string { return m.Mark("c", "x") })
+		return []any{m.Scan(v), bounds(m.Get("c"))}
+	},
+	"MarkWith": func(m *Manager, global bool) any {
+		v := pick(global, func() string { return MarkWith("c", "x", WithPayload(2)) }, func() string { return m.MarkWith("c", "x", WithPayload(2)) })
+		return []any{m.Scan(v), bounds(m.Get("c"))}
+	},
+	"MarkFunc": func(m *Manager, global bool) any {
+		fn := func(ZoneEvent) tea.Cmd { return func() tea.Msg { return "c" } }
+		v := pick(global, func() string { return MarkFunc("c", "x", fn) }, func() string { return m.MarkFunc("c", "x", fn) })
+		_ = m.Scan(v)
+		return msgOf(m.Dispatch(tea.MouseClickMsg{X: 0, Y: 0}))
+	},
+	"MarkLink": func(m *Manager, global bool) any {
+		v := pick(global, func() string { return MarkLink("c", "https://example.com", "x") }, func() string { return m.MarkLink("c", "https://example.com", "x") })
+		_ = m.Scan(v)
+		return []any{bounds(m.Get("c")), m.Get("c").meta.link}
+	},
+	"Clear": func(m *Manager, global bool) any {
+		do(global, func() { Clear("a") }, func() { m.Clear("a") })
+		return []any{bounds(m.Get("a")), bounds(m.Get("b"))}
+	},
+	"Get": func(m *Manager, global bool) any {
+		return bounds(pick(global, func() *ZoneInfo { return Get("a") }, func() *ZoneInfo { return m.Get("a") }))
+	},
+	"Scan": func(m *Manager, global bool) any {
+		v := m.Mark("c", "xyz")
+		return []any{pick(global, func() string { return Scan(v) }, func() string { return m.Scan(v) }), bounds(m.Get("c"))}
+	},
+	"Flush": func(m *Manager, global bool) any {
+		do(global, Flush, m.Flush)
+		return bounds(m.Get("a"))
+	},
+	"AnyInBounds": func(m *Manager, global bool) any {
+		model := &parityModel{}
+		do(global, func() { AnyInBounds(model, parityClick) }, func() { m.AnyInBounds(model, parityClick) })
+		return model.ids
+	},
+	"AnyInBoundsAndUpdate": func(m *Manager, global bool) any {
+		model, _ := pick(global, func() tea.Model {
+			model, _ := AnyInBoundsAndUpdate(&parityModel{}, parityClick)
+			return model
+		}, func() tea.Model {
+			model, _ := m.AnyInBoundsAndUpdate(&parityModel{}, parityClick)
+			return model
+		}).(*parityModel)
+		return model.ids
+	},
+	"Wrap": func(m *Manager, global bool) any {
+		w := pick(global, func() tea.Model { return Wrap(testWrapModel{}) }, func() tea.Model { return m.Wrap(testWrapModel{}) })
+		w, _ = w.Update(parityClick)
+		return []any{w.View().Content, len(Unwrap(w).(testWrapModel).received)}
+	},
+	"ScanView": func(m *Manager, global bool) any {
+		view := tea.NewView(m.Mark("c", "xyz"))
+		view = pick(global, func() tea.View { return ScanView(view) }, func() tea.View { return m.ScanView(view) })
+		return []any{view.Content, view.MouseMode, bounds(m.Get("c"))}
+	},
+	"Dispatch": func(m *Manager, global bool) any {
+		return msgOf(pick(global, func() tea.Cmd { return Dispatch(parityHandler) }, func() tea.Cmd { return m.Dispatch(parityHandler) }))
+	},
+	"RouteScroll": func(m *Manager, global bool) any {
+		msg, ok := RouteScroll(parityWheel)
+		if !global {
+			msg, ok = m.RouteScroll(parityWheel)
+		}
+		return []any{msg.ID, msg.Delta, ok}
+	},
+	"Capture": func(m *Manager, global bool) any {
+		do(global, func() { Capture("a") }, func() { m.Capture("a") })
+		return m.Captured()
+	},
+	"Release": func(m *Manager, global bool) any {
+		m.Capture("a")
+		do(global, Release, m.Release)
+		return m.Captured()
+	},
+	"Captured": func(m *Manager, global bool) any {
+		m.Capture("b")
+		return pick(global, Captured, m.Captured)
+	},
+	"Quit": func(m *Manager, global bool) any {
+		_, quit := msgOf(pick(global, Quit, m.Quit)).(tea.QuitMsg)
+		return quit
+	},
+	"ResetPointer": func(m *Manager, global bool) any {
+		_ = pick(global, func() tea.Cmd { return UpdatePointer(parityMotion) }, func() tea.Cmd { return m.UpdatePointer(parityMotion) })
+		return msgOf(pick(global, ResetPointer, m.ResetPointer))
+	},
+	"UpdatePointer": func(m *Manager, global bool) any {
+		return msgOf(pick(global, func() tea.Cmd { return UpdatePointer(parityMotion) }, func() tea.Cmd { return m.UpdatePointer(parityMotion) }))
+	},
+	"SetTooltipDelay": func(m *Manager, global bool) any {
+		do(global, func() { SetTooltipDelay(time.Hour) }, func() { m.SetTooltipDelay(time.Hour) })
+		return m.tooltip.delay
+	},
+	"SetTooltipStyle": func(m *Manager, global bool) any {
+		style := lipgloss.NewStyle().Bold(true)
+		do(global, func() { SetTooltipStyle(style) }, func() { m.SetTooltipStyle(style) })
+		return m.tooltip.style.GetBold()
+	},
+	"UpdateTooltip": func(m *Manager, global bool) any {
+		cmd := pick(global, func() tea.Cmd { return UpdateTooltip(parityMotion) }, func() tea.Cmd { return m.UpdateTooltip(parityMotion) })
+		_ = m.UpdateTooltip(msgOf(cmd))
+		_, ok := m.Tooltip(0, 0)
+		return ok
+	},
+	"Tooltip": func(m *Manager, global bool) any {
+		_ = m.UpdateTooltip(msgOf(m.UpdateTooltip(parityMotion)))
+		overlay, ok := Tooltip(80, 24)
+		if !global {
+			overlay, ok = m.Tooltip(80, 24)
+		}
+		return []any{overlay, ok}
+	},
+	"SetMenu": func(m *Manager, global bool) any {
+		do(global, func() { SetMenu("b", MenuItem{ID: "x", Label: "X"}) }, func() { m.SetMenu("b", MenuItem{ID: "x", Label: "X"}) })
+		return m.menu.items["b"]
+	},
+	"SetMenuStyles": func(m *Manager, global bool) any {
+		styles := MenuStyles{Item: lipgloss.NewStyle().Bold(true)}
+		do(global, func() { SetMenuStyles(styles) }, func() { m.SetMenuStyles(styles) })
+		return m.menu.styles.Item.GetBold()
+	},
+	"MenuItemID": func(m *Manager, global bool) any {
+		_, _ = m.UpdateMenu(parityRight)
+		_ = m.Scan("title\n[ab] cd")
+		id := pick(global, func() string { return MenuItemID(MenuItem{ID: "copy"}) }, func() string { return m.MenuItemID(MenuItem{ID: "copy"}) })
+		z := m.Get(id)
+		return []any{strings.HasSuffix(id, "copy"), z.IsZero(), z.StartX, z.StartY, z.EndX, z.EndY}
+	},
+	"MenuOpen": func(m *Manager, global bool) any {
+		_, _ = m.UpdateMenu(parityRight)
+		return pick(global, MenuOpen, m.MenuOpen)
+	},
+	"CloseMenu": func(m *Manager, global bool) any {
+		_, _ = m.UpdateMenu(parityRight)
+		do(global, CloseMenu, m.CloseMenu)
+		return m.MenuOpen()
+	},
+	"Menu": func(m *Manager, global bool) any {
+		_, _ = m.UpdateMenu(parityRight)
+		overlay, ok := Menu()
+		if !global {
+			overlay, ok = m.Menu()
+		}
+		return []any{overlay, ok}
+	},
+	"UpdateMenu": func(m *Manager, global bool) any {
+		cmd, handled := UpdateMenu(parityRight)
+		if !global {
+			cmd, handled = m.UpdateMenu(parityRight)
+		}
+		return []any{msgOf(cmd), handled, m.MenuOpen()}
+	},
+	"SetSelectionStyle": func(m *Manager, global bool) any {
+		style := lipgloss.NewStyle().Bold(true)
+		do(global, func() { SetSelectionStyle(style) }, func() { m.SetSelectionStyle(style) })
+		return m.selection.style.GetBold()
+	},
+	"UpdateSelection": func(m *Manager, global bool) any {
+		var msgs []tea.Msg
+		for _, msg := range []tea.Msg{
+			tea.MouseClickMsg{X: 0, Y: 0, Button: tea.MouseLeft},
+			tea.MouseMotionMsg{X: 4, Y: 0, Button: tea.MouseLeft},
+			tea.MouseReleaseMsg{X: 4, Y: 0, Button: tea.MouseLeft},
+		} {
+			msgs = append(msgs, msgOf(pick(global, func() tea.Cmd { return UpdateSelection(msg) }, func() tea.Cmd { return m.UpdateSelection(msg) })))
+		}
+		return msgs
+	},
+	"Selection": func(m *Manager, global bool) any {
+		_ = m.UpdateSelection(tea.MouseClickMsg{X: 0, Y: 0, Button: tea.MouseLeft})
+		_ = m.UpdateSelection(tea.MouseMotionMsg{X: 2, Y: 0, Button: tea.MouseLeft})
+		text, ok := Selection()
+		if !global {
+			text, ok = m.Selection()
+		}
+		return []any{text, ok}
+	},
+	"ClearSelection": func(m *Manager, global bool) any {
+		_ = m.UpdateSelection(tea.MouseClickMsg{X: 0, Y: 0, Button: tea.MouseLeft})
+		_ = m.UpdateSelection(tea.MouseMotionMsg{X: 2, Y: 0, Button: tea.MouseLeft})
+		do(global, ClearSelection, m.ClearSelection)
+		_, ok := m.Selection()
+		return ok
+	},
+	"CopySelection": func(m *Manager, global bool) any {
+		_ = m.UpdateSelection(tea.MouseClickMsg{X: 0, Y: 0, Button: tea.MouseLeft})
+		_ = m.UpdateSelection(tea.MouseMotionMsg{X: 2, Y: 0, Button: tea.MouseLeft})
+		return fmt.Sprint(msgOf(pick(global, CopySelection, m.CopySelection)))
+	},
+	"HighlightSelection": func(m *Manager, global bool) any {
+		_ = m.UpdateSelection(tea.MouseClickMsg{X: 0, Y: 0, Button: tea.MouseLeft})
+		_ = m.UpdateSelection(tea.MouseMotionMsg{X: 2, Y: 0, Button: tea.MouseLeft})
+		return pick(global, func() string { return HighlightSelection("title") }, func() string { return m.HighlightSelection("title") })
+	},
+	"Zones": func(m *Manager, global bool) any {
+		var out []string
+		for _, z := range pick(global, Zones, m.Zones) {
+			out = append(out, bounds(z))
+		}
+		return out
+	},
+	"Annotate": func(m *Manager, global bool) any {
+		return pick(global, func() string { return Annotate("title\n[ab] cd") }, func() string { return m.Annotate("title\n[ab] cd") })
+	},
+	"SetInspector": func(m *Manager, global bool) any {
+		do(global, func() { SetInspector(true) }, func() { m.SetInspector(true) })
+		return m.Inspecting()
+	},
+	"Inspecting": func(m *Manager, global bool) any {
+		m.SetInspector(true)
+		return pick(global, Inspecting, m.Inspecting)
+	},
+	"SetInspectorStyles": func(m *Manager, global bool) any {
+		styles := InspectorStyles{Status: lipgloss.NewStyle().Bold(true)}
+		do(global, func() { SetInspectorStyles(styles) }, func() { m.SetInspectorStyles(styles) })
+		return m.inspector.styles.Status.GetBold()
+	},
+	"UpdateInspector": func(m *Manager, global bool) any {
+		do(global, func() { UpdateInspector(parityMotion) }, func() { m.UpdateInspector(parityMotion) })
+		return *m.inspector.mouse
+	},
+	"Diagnostics": func(m *Manager, global bool) any {
+		_ = m.Scan(m.Mark("c", "x") + m.Mark("c", "y"))
+		return pick(global, Diagnostics, m.Diagnostics)
+	},
+	"Scope": func(m *Manager, global bool) any {
+		scope := pick(global, Scope, m.Scope)
+		_ = m.Scan(scope.Mark("c", "x"))
+		local, ok := scope.Local(scope.Get("c").ID())
+		return []any{scope.ZoneManager() == m, local, ok}
+	},
+	"SetLogger": func(m *Manager, global bool) any {
+		var buf bytes.Buffer
+		logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+			ReplaceAttr: func(_ []string, attr slog.Attr) slog.Attr {
+				if attr.Key == slog.TimeKey {
+					return slog.Attr{}
+				}
+				return attr
+			},
+		}))
+		do(global, func() { SetLogger(logger) }, func() { m.SetLogger(logger) })
+		_ = m.Scan(m.Mark("c", "x") + m.Mark("c", "y"))
+		return buf.String()
+	},
+}
+
+// TestGlobalParity asserts that each global function has the same result and
+// effect as the matching method, called on DefaultManager.
+func TestGlobalParity(t *testing.T) {
+	prev := DefaultManager
+	defer SetDefault(prev)
+
+	for name, fn := range parityCases {
+		t.Run(name, func(t *testing.T) {
+			instance := newParityManager()
+			defer instance.Close()
+
+			global := newParityManager()
+			defer global.Close()
+
+			want := fn(instance, false)
+
+			SetDefault(global)
+			got := fn(global, true)
+
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("global: got %#v, want %#v", got, want)
+			}
+		})
+	}
+}
+
+// TestGlobalParityCoverage ensures every global wrapper in manager_global.go is
+// covered by TestGlobalParity.
+func TestGlobalParityCoverage(t *testing.T) {
+	file, err := parser.ParseFile(token.NewFileSet(), "manager_global.go", nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Functions which manage DefaultManager itself, rather than wrapping a method.
+	skip := map[string]bool{"NewGlobal": true, "SetDefault": true}
+
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv != nil || !fn.Name.IsExported() || skip[fn.Name.Name] {
+			continue
+		}
+
+		if _, ok := parityCases[fn.Name.Name]; !ok {
+			t.Errorf("global function %s has no parity test case", fn.Name.Name)
+		}
+	}
+}
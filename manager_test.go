@@ -5,10 +5,13 @@
 package zone
 
 import (
+	"context"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
 
+	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
 )
 
@@ -211,7 +214,248 @@ func TestClose(t *testing.T) {
 	}
 }
 
+func TestFlush(t *testing.T) {
+	mgr := New()
+
+	for i := range 50 {
+		_ = mgr.Scan(strings.Repeat("a", i) + mgr.Mark("foo", "b"))
+	}
+	mgr.Flush()
+
+	if xy := mgr.Get("foo"); xy.IsZero() || xy.StartX != 49 {
+		t.Errorf("got %#v, want zone from the last scan", xy)
+	}
+
+	mgr.Close()
+
+	done := make(chan struct{})
+	go func() {
+		mgr.Flush()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("expected Flush() to return after Close()")
+	}
+}
+
+// requireReturns fails the test if fn doesn't return within a second.
+func requireReturns(t *testing.T, name string, fn func()) {
+	t.Helper()
+
+	done := make(chan struct{})
+	go func() {
+		fn()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatalf("expected %s to return", name)
+	}
+}
+
+func TestScanAfterClose(t *testing.T) {
+	mgr := New(WithBufferSize(1))
+	marked := "a" + mgr.Mark("foo", "b") + "c"
+	mgr.Close()
+
+	// More scans than the queue can hold, which previously blocked forever once
+	// the worker had exited.
+	requireReturns(t, "Scan() after Close()", func() {
+		for range 10 {
+			if got := mgr.Scan(marked); got != "abc" {
+				t.Errorf("got %q, want %q", got, "abc")
+			}
+		}
+	})
+
+	requireReturns(t, "SetEnabled() after Close()", func() {
+		mgr.SetEnabled(false)
+	})
+}
+
+func TestScanManyZones(t *testing.T) {
+	mgr := New()
+	defer mgr.Close()
+
+	var b strings.Builder
+	for i := range 1000 {
+		b.WriteString(mgr.Mark(strconv.Itoa(i), "x"))
+	}
+
+	requireReturns(t, "Scan()", func() {
+		_ = mgr.Scan(b.String())
+	})
+	mgr.Flush()
+
+	if got := len(mgr.Zones()); got != 1000 {
+		t.Errorf("got %d zones, want 1000", got)
+	}
+}
+
+func TestScanCoalesce(t *testing.T) {
+	mgr := New(WithBufferSize(1))
+	defer mgr.Close()
+
+	// Stall the worker, so the queue fills up.
+	mgr.zoneMu.Lock()
+
+	flushed := make(chan struct{})
+	requireReturns(t, "Scan() with a stalled worker", func() {
+		for i := range 10 {
+			_ = mgr.Scan(strings.Repeat("a", i) + mgr.Mark("foo", "b"))
+
+			if i == 5 {
+				// Flush requests queued between scans are acknowledged once a later
+				// view is stored, even if the view they were queued after is dropped.
+				go func() {
+					mgr.Flush()
+					close(flushed)
+				}()
+				time.Sleep(10 * time.Millisecond)
+			}
+		}
+	})
+
+	mgr.zoneMu.Unlock()
+
+	select {
+	case <-flushed:
+	case <-time.After(time.Second):
+		t.Fatal("expected Flush() to return")
+	}
+
+	mgr.Flush()
+	if xy := mgr.Get("foo"); xy.IsZero() || xy.StartX != 9 {
+		t.Errorf("got %#v, want zone from the last scan", xy)
+	}
+}
+
+func TestCloseLifecycle(t *testing.T) {
+	mgr := New()
+	marked := "a" + mgr.Mark("foo", "b") + "c"
+	_ = mgr.Scan(marked)
+	mgr.Flush()
+
+	mgr.Close()
+	mgr.Close()
+
+	if !mgr.Closed() {
+		t.Fatal("expected manager to be closed")
+	}
+	if xy := mgr.Get("foo"); !xy.IsZero() {
+		t.Errorf("%#v fetched, but closed", xy)
+	}
+
+	mgr.SetEnabled(true)
+	if mgr.Enabled() {
+		t.Error("expected closed manager to stay disabled")
+	}
+
+	requireReturns(t, "methods after Close()", func() {
+		if got := mgr.Mark("bar", "b"); got != "b" {
+			t.Errorf("got %q, want unmarked output", got)
+		}
+		if got := mgr.Scan(marked); got != "abc" {
+			t.Errorf("got %q, want %q", got, "abc")
+		}
+		mgr.Flush()
+		mgr.Reset()
+
+		w := mgr.Wrap(testWrapModel{})
+		_, _ = w.Update(tea.MouseClickMsg{X: 1, Y: 0, Button: tea.MouseLeft})
+		_ = w.View()
+	})
+}
+
+func TestNewWithContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	mgr := NewWithContext(ctx)
+
+	_ = mgr.Scan(mgr.Mark("foo", "b"))
+	mgr.Flush()
+	if mgr.Get("foo").IsZero() {
+		t.Fatal("expected zone to be stored")
+	}
+
+	cancel()
+	if !mgr.Closed() {
+		t.Error("expected manager to be closed once the context is done")
+	}
+
+	// Zones are dropped asynchronously, once the context is done.
+	deadline := time.Now().Add(time.Second)
+	for !mgr.Get("foo").IsZero() {
+		if time.Now().After(deadline) {
+			t.Fatal("expected zones to be dropped")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestReset(t *testing.T) {
+	mgr := New()
+	defer mgr.Close()
+
+	old := mgr.Mark("foo", "b")
+	mgr.SetMenu("foo", MenuItem{Label: "Copy"})
+	_ = mgr.Scan(old)
+	mgr.Flush()
+	mgr.Capture("foo")
+
+	mgr.Reset()
+	mgr.Flush()
+
+	if xy := mgr.Get("foo"); !xy.IsZero() {
+		t.Errorf("%#v fetched after reset", xy)
+	}
+	if got := mgr.registrySize(); got != 0 {
+		t.Errorf("got registry size %d, want 0", got)
+	}
+	if got := mgr.Captured(); got != "" {
+		t.Errorf("got captured %q, want none", got)
+	}
+	if mgr.Mark("foo", "b") == old {
+		t.Error("expected a new marker after reset")
+	}
+
+	// Markers rendered before the reset are unknown.
+	_ = mgr.Scan(old)
+	mgr.Flush()
+	if xy := mgr.Get("foo"); !xy.IsZero() {
+		t.Errorf("%#v fetched from a marker rendered before reset", xy)
+	}
+}
+
 func TestGlobalInitialize(_ *testing.T) {
 	NewGlobal()
 	NewGlobal()
 }
+
+func TestGlobalReinitialize(t *testing.T) {
+	prev := DefaultManager
+	defer SetDefault(prev)
+
+	mgr := New()
+	SetDefault(mgr)
+
+	NewGlobal()
+	if DefaultManager != mgr {
+		t.Error("expected NewGlobal() to keep an open manager")
+	}
+
+	Close()
+	if !Closed() {
+		t.Error("expected global manager to be closed")
+	}
+
+	NewGlobal()
+	if DefaultManager == mgr || Closed() {
+		t.Error("expected NewGlobal() to replace a closed manager")
+	}
+	DefaultManager.Close()
+}
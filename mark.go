@@ -0,0 +1,65 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package zone
+
+import tea "charm.land/bubbletea/v2"
+
+// zoneMeta holds additional information about a zone, provided when marking
+// the zone with MarkWith().
+type zoneMeta struct {
+	payload    any
+	handler    HandlerFunc
+	scrollable bool
+	canScroll  ScrollFunc
+	pointer    PointerShape
+	link       string
+	tooltip    string
+
+	nonSelectable bool
+}
+
+// MarkOption is an option which can be provided to MarkWith(), to attach
+// additional information to a zone.
+type MarkOption func(*zoneMeta)
+
+// WithPayload attaches an arbitrary payload to the zone, which can be retrieved
+// using ZoneInfo.Payload(), and is included in zone messages (e.g. MsgZoneClick).
+// This is useful to avoid having to map IDs back to the item they represent.
+func WithPayload(payload any) MarkOption {
+	return func(meta *zoneMeta) {
+		meta.payload = payload
+	}
+}
+
+// HandlerFunc is a function which is invoked when a mouse event occurs within
+// the bounds of a zone. See WithHandler().
+type HandlerFunc func(evt ZoneEvent) tea.Cmd
+
+// WithHandler registers a handler for the zone, which is invoked by Dispatch()
+// (and by wrapped models, see Wrap()) for each mouse event that occurs within
+// the bounds of the zone. The handler is only valid for the frame it was
+// rendered in, so it must be provided on every render.
+func WithHandler(fn HandlerFunc) MarkOption {
+	return func(meta *zoneMeta) {
+		meta.handler = fn
+	}
+}
+
+// ScrollFunc reports whether a zone is able to scroll by delta (negative being
+// up/left, positive being down/right). If horizontal is true, the scroll is
+// horizontal. See WithScrollable().
+type ScrollFunc func(delta int, horizontal bool) bool
+
+// WithScrollable marks the zone as scrollable, so mouse wheel events over the
+// zone are routed to it as a MsgZoneScroll message (see RouteScroll()). If
+// canScroll is provided, it is used to check if the zone is at its scroll limit,
+// in which case the event is routed to the next scrollable parent zone instead.
+// If canScroll is nil, the zone is always considered scrollable.
+func WithScrollable(canScroll ScrollFunc) MarkOption {
+	return func(meta *zoneMeta) {
+		meta.scrollable = true
+		meta.canScroll = canScroll
+	}
+}
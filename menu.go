@@ -0,0 +1,349 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package zone
+
+import (
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+)
+
+// MenuItem is an item of a context menu. See Manager.SetMenu().
+type MenuItem struct {
+	ID       string // ID of the item, used to identify it in MsgZoneMenuSelect.
+	Label    string // Label of the item, as shown in the menu.
+	Disabled bool   // Disabled items are shown, but can't be selected.
+}
+
+// MsgZoneMenuSelect is sent when an item of a context menu is selected. See
+// Manager.SetMenu().
+type MsgZoneMenuSelect struct {
+	Zone *ZoneInfo // The zone the context menu was opened on.
+	Item MenuItem  // The item that was selected.
+}
+
+// MenuStyles are the styles used to render context menus. See
+// Manager.SetMenuStyles().
+type MenuStyles struct {
+	Menu     lipgloss.Style // Style of the menu itself, wrapping all items.
+	Item     lipgloss.Style // Style of each item.
+	Selected lipgloss.Style // Style of the item under the mouse, or selected with the keyboard.
+	Disabled lipgloss.Style // Style of disabled items.
+}
+
+// DefaultMenuStyles are the default styles used to render context menus.
+var DefaultMenuStyles = MenuStyles{
+	Menu:     lipgloss.NewStyle().Border(lipgloss.RoundedBorder()),
+	Item:     lipgloss.NewStyle().Padding(0, 1),
+	Selected: lipgloss.NewStyle().Padding(0, 1).Reverse(true),
+	Disabled: lipgloss.NewStyle().Padding(0, 1).Faint(true),
+}
+
+// menuState holds the state of the context menu.
+type menuState struct {
+	manager *Manager
+	prefix  string // Prefix of the zone IDs of menu items.
+	styles  MenuStyles
+	items   map[string][]MenuItem // zone ID -> menu items.
+
+	// Window size, used to keep the menu within the window.
+	width  int
+	height int
+
+	// State of the open menu, if any.
+	zone     *ZoneInfo
+	open     []MenuItem
+	selected int
+	overlay  Overlay // Overlay of the menu, with zone markers stripped.
+	marked   string  // Content of the overlay, with each item marked as a zone.
+}
+
+// SetMenu registers a context menu for the zone with the provided ID, which is
+// opened at the mouse position when the right mouse button is pressed within the
+// zone (see UpdateMenu()). If zones are nested, the innermost zone with a menu
+// is used. If no items are provided, the menu is removed.
+//
+// The menu is closed when clicking outside of it, or when pressing escape. Items
+// can be selected with the mouse, or with the up/down and enter keys, in which
+// case a MsgZoneMenuSelect message is sent. Other keys are passed through while
+// the menu is open.
+//
+// Each item of the open menu is a zone (see MenuItemID()), found by Scan() at
+// the position of the menu, on top of the zones of the view, so the menu must be
+// rendered (and the view scanned) before its items can be selected with the
+// mouse.
+func (m *Manager) SetMenu(id string, items ...MenuItem) {
+	m.menuMu.Lock()
+	defer m.menuMu.Unlock()
+
+	if len(items) == 0 {
+		delete(m.menu.items, id)
+		return
+	}
+
+	m.menu.items[id] = items
+}
+
+// SetMenuStyles sets the styles used to render context menus. Defaults to
+// DefaultMenuStyles.
+func (m *Manager) SetMenuStyles(styles MenuStyles) {
+	m.menuMu.Lock()
+	m.menu.styles = styles
+	if m.menu.zone != nil {
+		m.menu.render()
+	}
+	m.menuMu.Unlock()
+}
+
+// MenuItemID returns the zone ID of a menu item, while its menu is open. See
+// SetMenu().
+func (m *Manager) MenuItemID(item MenuItem) string {
+	m.menuMu.Lock()
+	defer m.menuMu.Unlock()
+	return m.menu.itemID(item)
+}
+
+// MenuOpen returns true if a context menu is currently open.
+func (m *Manager) MenuOpen() bool {
+	m.menuMu.Lock()
+	defer m.menuMu.Unlock()
+	return m.menu.zone != nil
+}
+
+// CloseMenu closes the context menu, if one is open.
+func (m *Manager) CloseMenu() {
+	m.menuMu.Lock()
+	m.menu.close()
+	m.menuMu.Unlock()
+}
+
+// Menu returns the open context menu, rendered and positioned at the mouse
+// position it was opened at (adjusted to stay inside the window). Use
+// Overlay.Composite() to draw it on top of the scanned view.
+//
+// Wrapped models (see Wrap()) composite the menu automatically.
+func (m *Manager) Menu() (overlay Overlay, ok bool) {
+	m.menuMu.Lock()
+	defer m.menuMu.Unlock()
+
+	if m.menu.zone == nil || !m.Enabled() {
+		return overlay, false
+	}
+
+	return m.menu.overlay, true
+}
+
+// UpdateMenu opens, closes and selects items of context menus, based on the
+// provided message. All messages should be passed to UpdateMenu(). If handled is
+// true, the message was consumed by the menu (e.g. a click on a menu item), and
+// shouldn't be processed further.
+//
+// Wrapped models (see Wrap()) call UpdateMenu() automatically.
+func (m *Manager) UpdateMenu(msg tea.Msg) (cmd tea.Cmd, handled bool) {
+	m.menuMu.Lock()
+	defer m.menuMu.Unlock()
+
+	if !m.Enabled() {
+		m.menu.close()
+		return nil, false
+	}
+
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.menu.width, m.menu.height = msg.Width, msg.Height
+		m.menu.close()
+	case tea.KeyPressMsg:
+		if m.menu.zone == nil {
+			return nil, false
+		}
+		return m.menu.key(msg)
+	case tea.MouseMsg:
+		if m.menu.zone != nil {
+			cmd, handled = m.menu.mouse(msg)
+			if handled {
+				return cmd, true
+			}
+		}
+
+		mouse := msg.Mouse()
+		if _, ok := msg.(tea.MouseClickMsg); !ok || mouse.Button != tea.MouseRight {
+			return nil, false
+		}
+
+		for _, zone := range innermost(m.findInBounds(msg)) {
+			if items := m.menu.items[zone.name]; len(items) > 0 {
+				m.menu.openAt(zone, items, mouse.X, mouse.Y)
+				return nil, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+// openAt opens the menu for zone at the provided position.
+func (s *menuState) openAt(zone *ZoneInfo, items []MenuItem, x, y int) {
+	s.zone = zone
+	s.open = items
+	s.selected = -1
+	s.overlay = Overlay{X: x, Y: y}
+	s.render()
+
+	w, h := s.overlay.Width(), s.overlay.Height()
+
+	if s.width > 0 && s.overlay.X+w > s.width {
+		s.overlay.X = max(0, s.width-w)
+	}
+
+	if s.height > 0 && s.overlay.Y+h > s.height {
+		s.overlay.Y = max(0, s.overlay.Y-h+1)
+	}
+}
+
+// close closes the menu, if one is open.
+func (s *menuState) close() {
+	s.zone = nil
+	s.open = nil
+	s.marked = ""
+}
+
+// itemID returns the zone ID of a menu item.
+func (s *menuState) itemID(item MenuItem) string {
+	return s.prefix + item.ID
+}
+
+// render renders the menu items into the overlay, keeping its position.
+func (s *menuState) render() {
+	var width int
+	for _, item := range s.open {
+		width = max(width, lipgloss.Width(item.Label))
+	}
+
+	rows := make([]string, len(s.open))
+	for i, item := range s.open {
+		style := s.styles.Item
+		switch {
+		case item.Disabled:
+			style = s.styles.Disabled
+		case i == s.selected:
+			style = s.styles.Selected
+		}
+
+		rows[i] = s.manager.Mark(s.itemID(item), style.Render(item.Label+strings.Repeat(" ", width-lipgloss.Width(item.Label))))
+	}
+
+	s.marked = s.styles.Menu.Render(lipgloss.JoinVertical(lipgloss.Left, rows...))
+	s.overlay.Content = s.manager.strip(s.marked)
+}
+
+// menuZones scans the open menu, if any, returning the zones of its items
+// positioned at the menu, and nested deeper than the provided zones of the view,
+// as the menu is drawn on top of them.
+func (m *Manager) menuZones(view []*ZoneInfo) []*ZoneInfo {
+	m.menuMu.Lock()
+	marked, overlay := m.menu.marked, m.menu.overlay
+	m.menuMu.Unlock()
+
+	if marked == "" {
+		return nil
+	}
+
+	depth := 0
+	for _, zone := range view {
+		depth = max(depth, zone.depth+1)
+	}
+
+	s := newScanner(m, marked)
+	s.run()
+
+	for _, zone := range s.zones {
+		zone.StartX += overlay.X
+		zone.EndX += overlay.X
+		zone.StartY += overlay.Y
+		zone.EndY += overlay.Y
+		zone.depth += depth
+	}
+
+	return s.zones
+}
+
+// selectedCmd closes the menu, returning a command which sends a
+// MsgZoneMenuSelect for the selected item, if it can be selected.
+func (s *menuState) selectedCmd() tea.Cmd {
+	if s.selected < 0 || s.selected >= len(s.open) || s.open[s.selected].Disabled {
+		return nil
+	}
+
+	msg := MsgZoneMenuSelect{Zone: s.zone, Item: s.open[s.selected]}
+	s.close()
+
+	return func() tea.Msg { return msg }
+}
+
+// key handles key presses while the menu is open. Keys which aren't used by the
+// menu aren't handled, so they still reach the model (e.g. ctrl+c).
+func (s *menuState) key(msg tea.KeyPressMsg) (cmd tea.Cmd, handled bool) {
+	switch msg.String() {
+	case "esc":
+		s.close()
+	case "up", "shift+tab":
+		s.selected = (max(s.selected, 0) - 1 + len(s.open)) % len(s.open)
+		s.render()
+	case "down", "tab":
+		s.selected = (s.selected + 1) % len(s.open)
+		s.render()
+	case "enter", "space":
+		return s.selectedCmd(), true
+	default:
+		return nil, false
+	}
+	return nil, true
+}
+
+// mouse handles mouse events while the menu is open. Events within the menu are
+// always handled. Presses outside of the menu close it, and are only handled if
+// they don't open another menu.
+func (s *menuState) mouse(msg tea.MouseMsg) (cmd tea.Cmd, handled bool) {
+	row := -1
+	for i, item := range s.open {
+		if s.manager.Get(s.itemID(item)).InBounds(msg) {
+			row = i
+			break
+		}
+	}
+
+	bounds := &ZoneInfo{
+		id:     "menu",
+		StartX: s.overlay.X,
+		StartY: s.overlay.Y,
+		EndX:   s.overlay.X + s.overlay.Width() - 1,
+		EndY:   s.overlay.Y + s.overlay.Height() - 1,
+	}
+
+	if !bounds.InBounds(msg) {
+		if _, ok := msg.(tea.MouseClickMsg); ok {
+			s.close()
+			return nil, msg.Mouse().Button != tea.MouseRight
+		}
+
+		if s.selected != -1 {
+			s.selected = -1
+			s.render()
+		}
+		return nil, false
+	}
+
+	if row != s.selected {
+		s.selected = row
+		s.render()
+	}
+
+	if release, ok := msg.(tea.MouseReleaseMsg); ok && release.Button == tea.MouseLeft {
+		return s.selectedCmd(), true
+	}
+
+	return nil, true
+}
@@ -0,0 +1,145 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package zone
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+)
+
+func TestMenu(t *testing.T) {
+	zm := New(WithSyncCommit(true))
+	defer zm.Close()
+
+	zm.SetMenuStyles(MenuStyles{
+		Menu:     lipgloss.NewStyle().Border(lipgloss.NormalBorder()),
+		Item:     lipgloss.NewStyle(),
+		Selected: lipgloss.NewStyle(),
+		Disabled: lipgloss.NewStyle(),
+	})
+	zm.SetMenu("file",
+		MenuItem{ID: "open", Label: "Open"},
+		MenuItem{ID: "delete", Label: "Delete", Disabled: true},
+		MenuItem{ID: "rename", Label: "Rename"},
+	)
+
+	// Items of the open menu are found when the view is scanned.
+	render := func() {
+		_ = zm.Scan("aaa\n" + zm.Mark("file", "file.txt") + "\n" + zm.Mark("other", "other"))
+	}
+	render()
+
+	_, _ = zm.UpdateMenu(tea.WindowSizeMsg{Width: 40, Height: 10})
+
+	// Left clicks, and right clicks outside of zones with menus, shouldn't open
+	// the menu.
+	for _, msg := range []tea.MouseMsg{
+		tea.MouseClickMsg{X: 1, Y: 1, Button: tea.MouseLeft},
+		tea.MouseClickMsg{X: 1, Y: 2, Button: tea.MouseRight},
+	} {
+		if _, handled := zm.UpdateMenu(msg); handled || zm.MenuOpen() {
+			t.Fatalf("%v: expected menu to stay closed", msg)
+		}
+	}
+
+	if _, handled := zm.UpdateMenu(tea.MouseClickMsg{X: 2, Y: 1, Button: tea.MouseRight}); !handled || !zm.MenuOpen() {
+		t.Fatal("expected menu to open")
+	}
+
+	overlay, ok := zm.Menu()
+	if !ok {
+		t.Fatal("expected menu overlay")
+	}
+
+	if overlay.X != 2 || overlay.Y != 1 || overlay.Width() != 8 || overlay.Height() != 5 {
+		t.Errorf("got overlay at %d,%d (%dx%d), want 2,1 (8x5)", overlay.X, overlay.Y, overlay.Width(), overlay.Height())
+	}
+	if strings.Contains(overlay.Content, "\x1b[") {
+		t.Errorf("expected zone markers to be stripped from the overlay, got %q", overlay.Content)
+	}
+
+	render()
+
+	item := zm.Get(zm.MenuItemID(MenuItem{ID: "rename"}))
+	if item.IsZero() || item.StartX != 3 || item.StartY != 4 || item.EndX != 8 || item.EndY != 4 {
+		t.Fatalf("got item zone %#v, want 3,4 to 8,4", item)
+	}
+
+	// Menu items are on top of the zones of the view.
+	if zones := innermost(zm.findInBounds(tea.MouseClickMsg{X: 4, Y: 2})); len(zones) != 2 || zones[0] != zm.Get(zm.MenuItemID(MenuItem{ID: "open"})) {
+		t.Errorf("got %d zones, want the menu item first", len(zones))
+	}
+
+	// Disabled items can't be selected.
+	if cmd, handled := zm.UpdateMenu(tea.MouseReleaseMsg{X: 4, Y: 3, Button: tea.MouseLeft}); !handled || cmd != nil {
+		t.Error("expected disabled item to be ignored")
+	}
+
+	cmd, handled := zm.UpdateMenu(tea.MouseReleaseMsg{X: 4, Y: 4, Button: tea.MouseLeft})
+	if !handled || cmd == nil {
+		t.Fatal("expected item to be selected")
+	}
+
+	msg, ok := cmd().(MsgZoneMenuSelect)
+	if !ok || msg.Item.ID != "rename" || msg.Zone.ID() != "file" {
+		t.Errorf("got %#v, want rename item of file zone", msg)
+	}
+
+	if zm.MenuOpen() {
+		t.Error("expected menu to close after selecting an item")
+	}
+
+	render()
+	if !zm.Get(zm.MenuItemID(MenuItem{ID: "rename"})).IsZero() {
+		t.Error("expected items of a closed menu not to be found")
+	}
+
+	// Escape closes the menu.
+	_, _ = zm.UpdateMenu(tea.MouseClickMsg{X: 2, Y: 1, Button: tea.MouseRight})
+	if _, handled = zm.UpdateMenu(tea.KeyPressMsg{Code: tea.KeyEscape}); !handled || zm.MenuOpen() {
+		t.Error("expected escape to close the menu")
+	}
+
+	// Keys which aren't used by the menu are passed through.
+	_, _ = zm.UpdateMenu(tea.MouseClickMsg{X: 2, Y: 1, Button: tea.MouseRight})
+	if _, handled = zm.UpdateMenu(tea.KeyPressMsg{Code: 'c', Mod: tea.ModCtrl}); handled || !zm.MenuOpen() {
+		t.Error("expected ctrl+c not to be handled by the menu")
+	}
+	_, _ = zm.UpdateMenu(tea.KeyPressMsg{Code: tea.KeyEscape})
+
+	// Clicking outside of the menu closes it.
+	_, _ = zm.UpdateMenu(tea.MouseClickMsg{X: 2, Y: 1, Button: tea.MouseRight})
+	if _, handled = zm.UpdateMenu(tea.MouseClickMsg{X: 30, Y: 8, Button: tea.MouseLeft}); !handled || zm.MenuOpen() {
+		t.Error("expected outside click to close the menu")
+	}
+
+	// Keyboard selection.
+	_, _ = zm.UpdateMenu(tea.MouseClickMsg{X: 2, Y: 1, Button: tea.MouseRight})
+	_, _ = zm.UpdateMenu(tea.KeyPressMsg{Code: tea.KeyDown})
+	if cmd, _ = zm.UpdateMenu(tea.KeyPressMsg{Code: tea.KeyEnter}); cmd == nil {
+		t.Fatal("expected item to be selected with the keyboard")
+	}
+
+	if msg = cmd().(MsgZoneMenuSelect); msg.Item.ID != "open" {
+		t.Errorf("got %q, want %q", msg.Item.ID, "open")
+	}
+
+	// Menus near the edge of the window should stay within it.
+	_, _ = zm.UpdateMenu(tea.MouseClickMsg{X: 39, Y: 9, Button: tea.MouseRight})
+	if _, ok := zm.Menu(); ok {
+		t.Fatal("expected no menu outside of zones")
+	}
+
+	_ = zm.Scan("aaa\n" + zm.Mark("file", "file.txt"))
+	_, _ = zm.UpdateMenu(tea.WindowSizeMsg{Width: 9, Height: 4})
+	_, _ = zm.UpdateMenu(tea.MouseClickMsg{X: 7, Y: 1, Button: tea.MouseRight})
+
+	if overlay, ok = zm.Menu(); !ok || overlay.X != 1 || overlay.Y != 0 {
+		t.Errorf("got overlay at %d,%d, want 1,0", overlay.X, overlay.Y)
+	}
+}
@@ -10,6 +10,12 @@ import (
 	tea "charm.land/bubbletea/v2"
 )
 
+// MsgZoneEnabledChanged is sent when the zone manager is enabled or disabled
+// using SetEnabledCmd().
+type MsgZoneEnabledChanged struct {
+	Enabled bool // Whether the manager is now enabled.
+}
+
 // MsgZoneInBounds is a message sent when the manager detects that a zone is within
 // bounds of a mouse event.
 type MsgZoneInBounds struct {
@@ -18,6 +24,185 @@ type MsgZoneInBounds struct {
 	Event tea.MouseMsg // The mouse event that caused the zone to be in bounds.
 }
 
+// ZoneEvent holds information about a mouse event which occurred within the
+// bounds of a zone.
+type ZoneEvent struct {
+	ID      string       // The ID of the zone, as provided to Mark().
+	Zone    *ZoneInfo    // The zone that is in bounds.
+	Payload any          // The payload provided when marking the zone, if any.
+	URL     string       // The URL of the zone, if marked with MarkLink().
+	Event   tea.MouseMsg // The mouse event that occurred within the zone.
+
+	// X and Y are the coordinates of the mouse event, relative to the top left
+	// cell of the zone (see ZoneInfo.RelativePos()). These may be outside of
+	// the zone while the zone has the mouse captured (see Manager.Capture()).
+	X, Y int
+}
+
+func newZoneEvent(zone *ZoneInfo, mouse tea.MouseMsg) ZoneEvent {
+	x, y, _ := zone.RelativePos(mouse)
+
+	evt := ZoneEvent{
+		ID:      zone.ID(),
+		Zone:    zone,
+		Payload: zone.Payload(),
+		Event:   mouse,
+		X:       x,
+		Y:       y,
+	}
+
+	if zone.meta != nil {
+		evt.URL = zone.meta.link
+	}
+
+	return evt
+}
+
+// MsgZoneClick is sent by a wrapped model (see Wrap()) when a mouse button is
+// pressed within the bounds of a zone.
+type MsgZoneClick struct{ ZoneEvent }
+
+// MsgZoneRelease is sent by a wrapped model (see Wrap()) when a mouse button is
+// released within the bounds of a zone.
+type MsgZoneRelease struct{ ZoneEvent }
+
+// MsgZoneWheel is sent by a wrapped model (see Wrap()) when the mouse wheel is
+// used within the bounds of a zone.
+type MsgZoneWheel struct{ ZoneEvent }
+
+// MsgZoneMotion is sent by a wrapped model (see Wrap()) when the mouse moves
+// within the bounds of a zone.
+type MsgZoneMotion struct{ ZoneEvent }
+
+// MsgZoneLink is sent by a wrapped model (see Wrap()) when the left mouse button
+// is released within the bounds of a zone marked with MarkLink(). The URL of the
+// link is available as ZoneEvent.URL.
+type MsgZoneLink struct{ ZoneEvent }
+
+// MsgZoneScroll is sent by a wrapped model (see Wrap()) when the mouse wheel is
+// used over a scrollable zone (see WithScrollable() and RouteScroll()).
+type MsgZoneScroll struct {
+	ZoneEvent
+
+	// Delta is the amount to scroll by, negative being up/left and positive
+	// being down/right.
+	Delta int
+
+	// Horizontal is true if the scroll is horizontal, either from a horizontal
+	// wheel, or a vertical wheel with the shift modifier held.
+	Horizontal bool
+}
+
+// scrollDelta returns the scroll delta and direction of the provided mouse
+// wheel event.
+func scrollDelta(mouse tea.MouseWheelMsg) (delta int, horizontal bool) {
+	switch mouse.Button { //nolint:exhaustive
+	case tea.MouseWheelUp:
+		delta = -1
+	case tea.MouseWheelDown:
+		delta = 1
+	case tea.MouseWheelLeft:
+		return -1, true
+	case tea.MouseWheelRight:
+		return 1, true
+	default:
+		return 0, false
+	}
+
+	return delta, mouse.Mod.Contains(tea.ModShift)
+}
+
+// RouteScroll returns a MsgZoneScroll for the innermost scrollable zone (see
+// WithScrollable()) under the provided mouse wheel event. If the innermost zone
+// is at its scroll limit, the event is routed to the next scrollable parent zone.
+// If no zone is able to scroll, ok will be false.
+func (m *Manager) RouteScroll(mouse tea.MouseWheelMsg) (msg MsgZoneScroll, ok bool) {
+	delta, horizontal := scrollDelta(mouse)
+	if delta == 0 {
+		return msg, false
+	}
+
+	for _, zone := range innermost(m.hitZones(mouse)) {
+		if zone.meta == nil || !zone.meta.scrollable {
+			continue
+		}
+
+		if zone.meta.canScroll != nil && !zone.meta.canScroll(delta, horizontal) {
+			continue
+		}
+
+		return MsgZoneScroll{
+			ZoneEvent:  newZoneEvent(zone, mouse),
+			Delta:      delta,
+			Horizontal: horizontal,
+		}, true
+	}
+
+	return msg, false
+}
+
+// zoneMsgs returns a typed zone message for each zone that is in the bounds of
+// the provided mouse event, in alphabetical sorted order of the ID. For mouse
+// wheel events, a MsgZoneScroll is also included if a scrollable zone is found.
+func (m *Manager) zoneMsgs(mouse tea.MouseMsg) []tea.Msg {
+	zones := m.hitZones(mouse)
+	msgs := make([]tea.Msg, 0, len(zones))
+
+	for _, zone := range zones {
+		evt := newZoneEvent(zone, mouse)
+
+		switch mouse := mouse.(type) {
+		case tea.MouseClickMsg:
+			msgs = append(msgs, MsgZoneClick{evt})
+		case tea.MouseReleaseMsg:
+			msgs = append(msgs, MsgZoneRelease{evt})
+
+			if evt.URL != "" && mouse.Button == tea.MouseLeft {
+				msgs = append(msgs, MsgZoneLink{evt})
+			}
+		case tea.MouseWheelMsg:
+			msgs = append(msgs, MsgZoneWheel{evt})
+		case tea.MouseMotionMsg:
+			msgs = append(msgs, MsgZoneMotion{evt})
+		}
+	}
+
+	if wheel, ok := mouse.(tea.MouseWheelMsg); ok {
+		if scroll, ok := m.RouteScroll(wheel); ok {
+			msgs = append(msgs, scroll)
+		}
+	}
+
+	return msgs
+}
+
+// Dispatch invokes the handler of each zone that is in the bounds of the
+// provided mouse event (see WithHandler() and MarkFunc()), in alphabetical sorted
+// order of the ID. If a zone has the mouse captured (see Capture()), only its
+// handler is invoked. The resulting commands are wrapped in tea.Batch().
+//
+// Wrapped models (see Wrap()) call Dispatch() automatically.
+func (m *Manager) Dispatch(mouse tea.MouseMsg) tea.Cmd {
+	cmd, _ := m.dispatch(mouse)
+	return cmd
+}
+
+// dispatch is the same as Dispatch(), however it also returns the number of
+// handlers invoked.
+func (m *Manager) dispatch(mouse tea.MouseMsg) (cmd tea.Cmd, handlers int) {
+	var cmds []tea.Cmd
+
+	for _, zone := range m.hitZones(mouse) {
+		if zone.meta == nil || zone.meta.handler == nil {
+			continue
+		}
+
+		cmds = append(cmds, zone.meta.handler(newZoneEvent(zone, mouse)))
+	}
+
+	return tea.Batch(cmds...), len(cmds)
+}
+
 func (m *Manager) findInBounds(mouse tea.MouseMsg) []*ZoneInfo {
 	var zones []*ZoneInfo
 
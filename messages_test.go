@@ -127,3 +127,92 @@ func TestAnyInBoundsAndUpdate(t *testing.T) {
 		t.Error("expected true")
 	}
 }
+
+type testDispatchMsg struct {
+	id string
+	x  int
+}
+
+func TestDispatch(t *testing.T) {
+	handler := func(evt ZoneEvent) tea.Cmd {
+		return func() tea.Msg {
+			return testDispatchMsg{id: evt.ID, x: evt.X}
+		}
+	}
+
+	_ = Scan("test\nfoo\naaa " + MarkFunc("foo", "bar\ntest123456789", handler) + " " + Mark("bar", "aaa") + "\nbaz")
+	time.Sleep(100 * time.Millisecond)
+
+	cmd := Dispatch(tea.MouseClickMsg{X: 5, Y: 2})
+	if cmd == nil {
+		t.Fatal("expected command from handler")
+	}
+
+	msg, ok := cmd().(testDispatchMsg)
+	if !ok || msg.id != "foo" || msg.x != 1 {
+		t.Errorf("got %#v, want %#v", msg, testDispatchMsg{id: "foo", x: 1})
+	}
+
+	// Zones without handlers shouldn't return any commands.
+	if cmd = Dispatch(tea.MouseClickMsg{X: 14, Y: 3}); cmd != nil {
+		t.Errorf("expected no command, got %#v", cmd())
+	}
+
+	// Handlers are only valid for the frame they were rendered in.
+	_ = Scan("test\nfoo\naaa " + Mark("foo", "bar\ntest123456789") + " aaa\nbaz")
+	time.Sleep(100 * time.Millisecond)
+
+	if cmd = Dispatch(tea.MouseClickMsg{X: 5, Y: 2}); cmd != nil {
+		t.Errorf("expected no command, got %#v", cmd())
+	}
+}
+
+func TestRouteScroll(t *testing.T) {
+	var innerAtLimit bool
+	inner := WithScrollable(func(delta int, _ bool) bool {
+		return !innerAtLimit || delta > 0
+	})
+
+	_ = Scan(MarkWith("outer", "aaaa\naa"+MarkWith("inner", "bb", inner)+"a", WithScrollable(nil)) + "\n" + Mark("static", "ccc"))
+	time.Sleep(100 * time.Millisecond)
+
+	tests := []struct {
+		name       string
+		msg        tea.MouseWheelMsg
+		atLimit    bool
+		want       string
+		delta      int
+		horizontal bool
+	}{
+		{"innermost", tea.MouseWheelMsg{X: 2, Y: 1, Button: tea.MouseWheelDown}, false, "inner", 1, false},
+		{"innermost-up", tea.MouseWheelMsg{X: 2, Y: 1, Button: tea.MouseWheelUp}, false, "inner", -1, false},
+		{"parent-at-limit", tea.MouseWheelMsg{X: 2, Y: 1, Button: tea.MouseWheelUp}, true, "outer", -1, false},
+		{"inner-not-at-limit", tea.MouseWheelMsg{X: 2, Y: 1, Button: tea.MouseWheelDown}, true, "inner", 1, false},
+		{"parent-only", tea.MouseWheelMsg{X: 0, Y: 0, Button: tea.MouseWheelDown}, false, "outer", 1, false},
+		{"horizontal", tea.MouseWheelMsg{X: 0, Y: 0, Button: tea.MouseWheelRight}, false, "outer", 1, true},
+		{"horizontal-shift", tea.MouseWheelMsg{X: 0, Y: 0, Button: tea.MouseWheelUp, Mod: tea.ModShift}, false, "outer", -1, true},
+		{"not-scrollable", tea.MouseWheelMsg{X: 0, Y: 3, Button: tea.MouseWheelDown}, false, "", 0, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			innerAtLimit = test.atLimit
+
+			msg, ok := RouteScroll(test.msg)
+			if ok != (test.want != "") {
+				t.Fatalf("got ok %v, want %v", ok, test.want != "")
+			}
+
+			if !ok {
+				return
+			}
+
+			if msg.ID != test.want || msg.Delta != test.delta || msg.Horizontal != test.horizontal {
+				t.Errorf(
+					"got %q (delta %d, horizontal %v), want %q (delta %d, horizontal %v)",
+					msg.ID, msg.Delta, msg.Horizontal, test.want, test.delta, test.horizontal,
+				)
+			}
+		})
+	}
+}
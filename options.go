@@ -0,0 +1,248 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package zone
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// DefaultBufferSize is the default number of scanned views which can be queued
+// to be stored by the worker. See WithBufferSize().
+const DefaultBufferSize = 8
+
+// Option is an option which can be provided to New(), to configure the manager.
+type Option func(*managerConfig)
+
+type managerConfig struct {
+	logger     *slog.Logger
+	hooks      Hooks
+	sync       bool
+	bufferSize int
+	width      WidthFunc
+	markerEnd  byte
+	evictAfter int
+	enabled    bool
+}
+
+// WidthFunc returns the printable cell width of s, which may contain ANSI escape
+// sequences (other than zone markers), which must not be counted. See
+// WithWidthFunc().
+type WidthFunc func(s string) int
+
+// WithSyncCommit sets whether zones are stored synchronously by Scan(), rather
+// than queued for a background worker. When enabled, Get() returns the zones of the last
+// scanned view as soon as Scan() returns, and no worker goroutine is started,
+// at the cost of Scan() waiting for readers of the zones (e.g. Get()). This is
+// disabled by default.
+func WithSyncCommit(enabled bool) Option {
+	return func(c *managerConfig) {
+		c.sync = enabled
+	}
+}
+
+// WithBufferSize sets the number of scanned views which can be queued to be
+// stored by the worker. Scan() never blocks on the worker: if the queue is full,
+// the oldest queued view is dropped. Defaults to DefaultBufferSize, with a
+// minimum of 1. Ignored when using WithSyncCommit().
+func WithBufferSize(size int) Option {
+	return func(c *managerConfig) {
+		c.bufferSize = max(1, size)
+	}
+}
+
+// WithWidthFunc sets the function used to calculate the position of zones,
+// which should match how the terminal renders the view. Defaults to a function
+// which sums the width of each rune, using go-runewidth. Use e.g.
+// ansi.StringWidth from github.com/charmbracelet/x/ansi to take grapheme
+// clusters into account.
+func WithWidthFunc(fn WidthFunc) Option {
+	return func(c *managerConfig) {
+		if fn != nil {
+			c.width = fn
+		}
+	}
+}
+
+// WithMarkerTerminator sets the final byte of the ANSI sequences used as zone
+// markers, which defaults to 'z'. This can be used if another component already
+// uses "ESC[<number>z" sequences. final must be one of the private final bytes
+// ('p' to '~'), so terminals ignore the markers if they're ever printed,
+// otherwise New() and NewWithContext() panic.
+func WithMarkerTerminator(final byte) Option {
+	return func(c *managerConfig) {
+		c.markerEnd = final
+	}
+}
+
+// WithRegistryEviction evicts IDs from the registry of the manager (which maps
+// IDs to their markers, see Mark()) if they haven't been found in a scanned view
+// for at least the provided number of scans. Otherwise, IDs are kept forever,
+// which may use a lot of memory when marking many short-lived IDs (e.g. an ID
+// per row of a large, changing table). If an evicted ID is marked again, a new
+// marker is generated. Disabled (0) by default.
+func WithRegistryEviction(scans int) Option {
+	return func(c *managerConfig) {
+		c.evictAfter = max(0, scans)
+	}
+}
+
+// WithEnabled sets whether the manager is initially enabled (see
+// Manager.SetEnabled()). Defaults to true.
+func WithEnabled(enabled bool) Option {
+	return func(c *managerConfig) {
+		c.enabled = enabled
+	}
+}
+
+// WithLogger sets the logger used by the manager. Diagnostics (see
+// Manager.Diagnostics()) are logged as warnings, and information about each
+// scanned view and dispatched mouse event (see Hooks) is logged at the debug
+// level. See also Manager.SetLogger().
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *managerConfig) {
+		c.logger = logger
+	}
+}
+
+// WithHooks registers hooks which are called with information about each
+// scanned view and dispatched mouse event. See Hooks.
+func WithHooks(hooks Hooks) Option {
+	return func(c *managerConfig) {
+		c.hooks = hooks
+	}
+}
+
+// Hooks receives information about what the manager is doing, which is useful
+// to debug issues like mouse events not reaching a zone, or slow renders. See
+// WithHooks(). Hooks are called synchronously, so they should return quickly.
+type Hooks interface {
+	// Scan is called after each call to Scan().
+	Scan(info ScanInfo)
+
+	// Dispatch is called by wrapped models (see Wrap()) for each mouse event.
+	Dispatch(info DispatchInfo)
+}
+
+// ScanInfo holds information about a scanned view. See Hooks.
+type ScanInfo struct {
+	Duration time.Duration // How long scanning the view took.
+	Zones    int           // Number of zones found in the view, including items of an open menu.
+	Registry int           // Number of IDs registered with the manager (see Mark()).
+
+	// Orphaned is the number of zone markers without a matching end marker,
+	// which were dropped (see DiagnosticUnbalanced).
+	Orphaned int
+
+	// Dropped is the number of zones with markers which aren't registered with
+	// the manager (e.g. marked by another manager), which were dropped.
+	Dropped int
+}
+
+// DispatchInfo holds information about how a mouse event was dispatched by a
+// wrapped model (see Wrap()). See Hooks.
+type DispatchInfo struct {
+	Event   tea.MouseMsg // The mouse event.
+	Enabled bool         // Whether the manager was enabled (see SetEnabled()).
+
+	// Zones are the IDs of the zones hit by the event, innermost first. If a
+	// zone has the mouse captured, only that zone is hit (see Capture()).
+	Zones    []string
+	Captured string // ID of the zone with the mouse captured, if any.
+
+	Mode     DispatchMode // How zone messages were delivered, for this type of event.
+	Messages int          // Number of zone messages sent to the model.
+	Handlers int          // Number of zone handlers invoked (see WithHandler()).
+
+	// Menu is true if the event was handled by an open context menu, in which
+	// case it wasn't sent to the model (see Manager.SetMenu()).
+	Menu bool
+}
+
+// observed returns true if information about scans and dispatched events is
+// reported to hooks or the logger, so it's only collected when needed.
+func (m *Manager) observed() bool {
+	if m.hooks != nil {
+		return true
+	}
+
+	logger := m.getLogger()
+	return logger != nil && logger.Enabled(context.Background(), slog.LevelDebug)
+}
+
+// getLogger returns the logger of the manager, if any.
+func (m *Manager) getLogger() *slog.Logger {
+	m.diagMu.Lock()
+	defer m.diagMu.Unlock()
+	return m.logger
+}
+
+// registrySize returns the number of IDs registered with the manager.
+func (m *Manager) registrySize() int {
+	m.idMu.RLock()
+	defer m.idMu.RUnlock()
+	return len(m.ids)
+}
+
+// reportScan reports information about a scanned view.
+func (m *Manager) reportScan(info ScanInfo) {
+	if m.hooks != nil {
+		m.hooks.Scan(info)
+	}
+
+	if logger := m.getLogger(); logger != nil {
+		logger.LogAttrs(
+			context.Background(),
+			slog.LevelDebug,
+			"zone: scan",
+			slog.Duration("duration", info.Duration),
+			slog.Int("zones", info.Zones),
+			slog.Int("registry", info.Registry),
+			slog.Int("orphaned", info.Orphaned),
+			slog.Int("dropped", info.Dropped),
+		)
+	}
+}
+
+// reportDispatch reports how a mouse event was dispatched, filling in the
+// zones hit by the event.
+func (m *Manager) reportDispatch(info DispatchInfo) {
+	if !m.observed() {
+		return
+	}
+
+	info.Enabled = m.Enabled()
+	info.Captured = m.Captured()
+	for _, zone := range innermost(m.hitZones(info.Event)) {
+		info.Zones = append(info.Zones, zone.name)
+	}
+
+	if m.hooks != nil {
+		m.hooks.Dispatch(info)
+	}
+
+	if logger := m.getLogger(); logger != nil {
+		event := info.Event.Mouse()
+
+		logger.LogAttrs(
+			context.Background(),
+			slog.LevelDebug,
+			"zone: dispatch",
+			slog.String("event", event.String()),
+			slog.Int("x", event.X),
+			slog.Int("y", event.Y),
+			slog.Bool("enabled", info.Enabled),
+			slog.Any("zones", info.Zones),
+			slog.String("captured", info.Captured),
+			slog.String("mode", info.Mode.String()),
+			slog.Int("messages", info.Messages),
+			slog.Int("handlers", info.Handlers),
+			slog.Bool("menu", info.Menu),
+		)
+	}
+}
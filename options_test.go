@@ -0,0 +1,223 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package zone
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+type testHooks struct {
+	scans      []ScanInfo
+	dispatches []DispatchInfo
+}
+
+func (h *testHooks) Scan(info ScanInfo) {
+	h.scans = append(h.scans, info)
+}
+
+func (h *testHooks) Dispatch(info DispatchInfo) {
+	h.dispatches = append(h.dispatches, info)
+}
+
+func TestHooks(t *testing.T) {
+	hooks := &testHooks{}
+	zm := New(WithHooks(hooks))
+	defer zm.Close()
+
+	other := New()
+	defer other.Close()
+
+	cut := zm.Mark("cut", "xyz")
+	cut = cut[:strings.Index(cut, "xyz")+3]
+
+	view := zm.Mark("outer", "ab "+zm.Mark("inner", "cd")) + " " + other.Mark("other", "ef") + " " + cut
+	if got := zm.Scan(view); got != "ab cd ef xyz" {
+		t.Fatalf("got view %q", got)
+	}
+	zm.Flush()
+
+	if len(hooks.scans) != 1 {
+		t.Fatalf("got %d scans, want 1", len(hooks.scans))
+	}
+
+	info := hooks.scans[0]
+	if info.Zones != 2 || info.Registry != 3 || info.Orphaned != 1 || info.Dropped != 1 {
+		t.Errorf("got scan info %+v", info)
+	}
+	if zm.Get("") != nil {
+		t.Error("expected zone from another manager to be dropped")
+	}
+
+	w := zm.Wrap(testWrapModel{}, WithClickDispatch(DispatchInstead))
+	_, _ = w.Update(tea.MouseClickMsg{X: 3, Y: 0, Button: tea.MouseLeft})
+
+	if len(hooks.dispatches) != 1 {
+		t.Fatalf("got %d dispatches, want 1", len(hooks.dispatches))
+	}
+
+	dispatch := hooks.dispatches[0]
+	if !dispatch.Enabled || dispatch.Mode != DispatchInstead || dispatch.Messages != 2 || dispatch.Handlers != 0 {
+		t.Errorf("got dispatch info %+v", dispatch)
+	}
+
+	// The press reaches all zones in bounds, and is only then captured.
+	if dispatch.Captured != "" || strings.Join(dispatch.Zones, ",") != "inner,outer" {
+		t.Errorf("got captured %q and zones %v, want inner and outer", dispatch.Captured, dispatch.Zones)
+	}
+	if id := zm.Captured(); id != "inner" {
+		t.Errorf("got captured %q after press, want %q", id, "inner")
+	}
+}
+
+func TestWithLogger(t *testing.T) {
+	var buf bytes.Buffer
+	zm := New(WithLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))))
+	defer zm.Close()
+
+	_ = zm.Scan(zm.Mark("foo", "bar"))
+	zm.Flush()
+
+	w := zm.Wrap(testWrapModel{})
+	_, _ = w.Update(tea.MouseMotionMsg{X: 1, Y: 0})
+
+	out := buf.String()
+	if !strings.Contains(out, `msg="zone: scan"`) || !strings.Contains(out, "zones=1 registry=1 orphaned=0 dropped=0") {
+		t.Errorf("expected scan to be logged, got %q", out)
+	}
+	if !strings.Contains(out, `msg="zone: dispatch"`) || !strings.Contains(out, "zones=[foo]") || !strings.Contains(out, "mode=after") {
+		t.Errorf("expected dispatch to be logged, got %q", out)
+	}
+}
+
+func TestWithSyncCommit(t *testing.T) {
+	zm := New(WithSyncCommit(true))
+	defer zm.Close()
+
+	_ = zm.Scan("ab " + zm.Mark("foo", "cd"))
+	if z := zm.Get("foo"); z.IsZero() || z.StartX != 3 || z.EndX != 4 {
+		t.Fatalf("expected zone to be stored before Scan() returns, got %+v", z)
+	}
+
+	_ = zm.Scan(zm.Mark("bar", "ef"))
+	if !zm.Get("foo").IsZero() || zm.Get("bar").IsZero() {
+		t.Error("expected zones of the previous view to be cleared")
+	}
+
+	zm.SetEnabled(false)
+	if !zm.Get("bar").IsZero() {
+		t.Error("expected zones to be cleared when disabled")
+	}
+
+	zm.Flush() // Must not block.
+}
+
+func TestWithBufferSize(t *testing.T) {
+	zm := New(WithBufferSize(0))
+	defer zm.Close()
+
+	_ = zm.Scan(zm.Mark("foo", "a") + zm.Mark("bar", "b"))
+	zm.Flush()
+
+	if zm.Get("foo").IsZero() || zm.Get("bar").IsZero() {
+		t.Error("expected zones to be stored")
+	}
+}
+
+func TestWithWidthFunc(t *testing.T) {
+	// A thumbs up with a skin tone modifier is a single grapheme cluster, which
+	// terminals supporting grapheme clustering render 2 cells wide.
+	view := func(zm *Manager) string {
+		return "\U0001F44D\U0001F3FD " + zm.Mark("foo", "bar")
+	}
+
+	zm := New(WithSyncCommit(true))
+	defer zm.Close()
+
+	_ = zm.Scan(view(zm))
+	if got := zm.Get("foo").StartX; got != 5 {
+		t.Errorf("got start %d with the default width, want 5", got)
+	}
+
+	zm = New(WithSyncCommit(true), WithWidthFunc(ansi.StringWidth))
+	defer zm.Close()
+
+	_ = zm.Scan(view(zm))
+	if got := zm.Get("foo").StartX; got != 3 {
+		t.Errorf("got start %d with the grapheme width, want 3", got)
+	}
+}
+
+func TestWithMarkerTerminator(t *testing.T) {
+	zm := New(WithSyncCommit(true), WithMarkerTerminator('|'))
+	defer zm.Close()
+
+	marked := zm.Mark("foo", "bar")
+	if !strings.HasSuffix(marked, "|") {
+		t.Fatalf("expected marker to end with '|', got %q", marked)
+	}
+
+	// Markers using the default terminator aren't zone markers.
+	if got := zm.Scan("\x1b[1234z" + marked); got != "\x1b[1234zbar" {
+		t.Errorf("got %q", got)
+	}
+	if zm.Get("foo").IsZero() {
+		t.Error("expected zone to be found")
+	}
+
+	// Building the option is fine, only creating a manager with it panics.
+	opts := []Option{WithMarkerTerminator('m')}
+
+	defer func() {
+		if r := recover(); r == nil || !strings.Contains(fmt.Sprint(r), "marker terminator 'm'") {
+			t.Errorf("got %v, want panic for invalid terminator", r)
+		}
+	}()
+	_ = New(opts...)
+}
+
+func TestWithRegistryEviction(t *testing.T) {
+	zm := New(WithSyncCommit(true), WithRegistryEviction(2))
+	defer zm.Close()
+
+	foo := zm.Mark("foo", "a")
+	_ = zm.Scan(foo + zm.Mark("bar", "b"))
+
+	for range 3 {
+		_ = zm.Scan(zm.Mark("bar", "b"))
+	}
+
+	if got := zm.registrySize(); got != 1 {
+		t.Errorf("got registry size %d, want 1", got)
+	}
+
+	// Evicted IDs get a new marker once marked again, and the old marker is
+	// unknown.
+	if zm.Mark("foo", "a") == foo {
+		t.Error("expected a new marker for an evicted ID")
+	}
+	_ = zm.Scan(foo)
+	if !zm.Get("foo").IsZero() {
+		t.Error("expected evicted marker to be dropped")
+	}
+}
+
+func TestWithEnabled(t *testing.T) {
+	zm := New(WithEnabled(false))
+	defer zm.Close()
+
+	if zm.Enabled() {
+		t.Error("expected manager to be disabled")
+	}
+	if got := zm.Mark("foo", "bar"); got != "bar" {
+		t.Errorf("got %q, want unmarked output", got)
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package zone
+
+import "charm.land/lipgloss/v2"
+
+// Overlay is rendered content which should be drawn on top of the view at a
+// specific position, like a tooltip (see Manager.Tooltip()).
+type Overlay struct {
+	Content string // The rendered content of the overlay.
+
+	X int // X is the x coordinate of the top left cell of the overlay (with 0 basis).
+	Y int // Y is the y coordinate of the top left cell of the overlay (with 0 basis).
+}
+
+// Width returns the width of the overlay content.
+func (o Overlay) Width() int {
+	return lipgloss.Width(o.Content)
+}
+
+// Height returns the height of the overlay content.
+func (o Overlay) Height() int {
+	return lipgloss.Height(o.Content)
+}
+
+// Composite draws the overlay on top of base (which should already be scanned,
+// see Scan()), returning the result. The result will be large enough to fit
+// both base and the overlay.
+func (o Overlay) Composite(base string) string {
+	top := lipgloss.NewLayer(o.Content).X(o.X).Y(o.Y).Z(1)
+	comp := lipgloss.NewCompositor(lipgloss.NewLayer(base), top)
+	bounds := comp.Bounds()
+
+	return lipgloss.NewCanvas(bounds.Max.X, bounds.Max.Y).Compose(comp).Render()
+}
+
+// place positions the overlay next to zone, within a window of the provided
+// width and height. The overlay is placed below the zone, unless it doesn't fit,
+// in which case it is flipped above the zone. It is then shifted left as needed
+// to fit within the window. If width or height is 0, the overlay isn't
+// constrained in that direction.
+func (o Overlay) place(zone *ZoneInfo, width, height int) Overlay {
+	w, h := o.Width(), o.Height()
+
+	o.X = zone.StartX
+	o.Y = zone.EndY + 1
+
+	if height > 0 && o.Y+h > height && zone.StartY-h >= 0 {
+		o.Y = zone.StartY - h
+	}
+
+	if width > 0 && o.X+w > width {
+		o.X = max(0, width-w)
+	}
+
+	return o
+}
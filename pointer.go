@@ -0,0 +1,105 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package zone
+
+import tea "charm.land/bubbletea/v2"
+
+// PointerShape is the shape of the mouse pointer, as understood by terminals
+// supporting OSC 22. Terminals which don't support OSC 22 will ignore it.
+type PointerShape string
+
+// Common pointer shapes. Most terminals supporting OSC 22 also support other
+// CSS cursor names, which can be used by converting them to a PointerShape.
+const (
+	PointerDefault    PointerShape = "default"
+	PointerHand       PointerShape = "pointer"
+	PointerText       PointerShape = "text"
+	PointerCrosshair  PointerShape = "crosshair"
+	PointerMove       PointerShape = "move"
+	PointerGrab       PointerShape = "grab"
+	PointerGrabbing   PointerShape = "grabbing"
+	PointerResizeEW   PointerShape = "ew-resize"
+	PointerResizeNS   PointerShape = "ns-resize"
+	PointerNotAllowed PointerShape = "not-allowed"
+	PointerWait       PointerShape = "wait"
+	PointerHelp       PointerShape = "help"
+)
+
+// sequence returns the OSC 22 sequence to set the pointer shape.
+func (p PointerShape) sequence() string {
+	return "\x1b]22;" + string(p) + "\x1b\\"
+}
+
+// WithPointer sets the shape of the mouse pointer while it is hovering over
+// the zone (see UpdatePointer()). If zones are nested, the innermost zone with
+// a pointer shape is used. Note that hover events are only reported by the
+// terminal when using tea.MouseModeAllMotion.
+func WithPointer(shape PointerShape) MarkOption {
+	return func(meta *zoneMeta) {
+		meta.pointer = shape
+	}
+}
+
+// UpdatePointer returns a command which updates the shape of the mouse pointer
+// to match the zone under the provided mouse event (see WithPointer()), or nil if
+// the shape doesn't need to change. When the mouse leaves a zone with a pointer
+// shape, the default pointer shape is restored.
+//
+// msg can be any message, however only mouse events will change the pointer
+// shape. If the zone manager is disabled, the default pointer shape is restored
+// on the next call.
+//
+// Wrapped models (see Wrap()) call UpdatePointer() automatically. Terminals keep
+// the pointer shape after the program exits, so quit with Quit() (or reset it
+// with ResetPointer()) when using pointer shapes.
+func (m *Manager) UpdatePointer(msg tea.Msg) tea.Cmd {
+	shape := PointerDefault
+
+	if m.Enabled() {
+		mouse, ok := msg.(tea.MouseMsg)
+		if !ok {
+			return nil
+		}
+
+		for _, zone := range innermost(m.hitZones(mouse)) {
+			if zone.meta != nil && zone.meta.pointer != "" {
+				shape = zone.meta.pointer
+				break
+			}
+		}
+	}
+
+	m.pointerMu.Lock()
+	defer m.pointerMu.Unlock()
+
+	if shape == m.pointer || (shape == PointerDefault && m.pointer == "") {
+		return nil
+	}
+
+	m.pointer = shape
+	return tea.Raw(shape.sequence())
+}
+
+// ResetPointer returns a command which restores the default shape of the mouse
+// pointer, or nil if it wasn't changed. Terminals keep the pointer shape after
+// the program exits, so it should be reset before quitting, see Quit().
+func (m *Manager) ResetPointer() tea.Cmd {
+	m.pointerMu.Lock()
+	defer m.pointerMu.Unlock()
+
+	if m.pointer == "" || m.pointer == PointerDefault {
+		return nil
+	}
+
+	m.pointer = PointerDefault
+	return tea.Raw(PointerDefault.sequence())
+}
+
+// Quit returns a command which restores the default shape of the mouse pointer
+// (see ResetPointer()), and then quits the program. Use it in place of tea.Quit
+// when using pointer shapes (see WithPointer()).
+func (m *Manager) Quit() tea.Cmd {
+	return tea.Sequence(m.ResetPointer(), tea.Quit)
+}
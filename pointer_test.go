@@ -0,0 +1,113 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package zone
+
+import (
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+func pointerSequence(t *testing.T, cmd tea.Cmd) string {
+	t.Helper()
+
+	if cmd == nil {
+		return ""
+	}
+
+	raw, ok := cmd().(tea.RawMsg)
+	if !ok {
+		t.Fatalf("got %T, want tea.RawMsg", cmd())
+	}
+
+	return raw.Msg.(string)
+}
+
+func TestUpdatePointer(t *testing.T) {
+	zm := New()
+	defer zm.Close()
+
+	_ = zm.Scan(zm.MarkWith("link", "foo "+zm.MarkWith("input", "bar", WithPointer(PointerText)), WithPointer(PointerHand)) + " " + zm.Mark("plain", "baz"))
+	time.Sleep(100 * time.Millisecond)
+
+	tests := []struct {
+		name string
+		msg  tea.Msg
+		want string
+	}{
+		{"outside-initial", tea.MouseMotionMsg{X: 20, Y: 0}, ""},
+		{"enter", tea.MouseMotionMsg{X: 0, Y: 0}, "\x1b]22;pointer\x1b\\"},
+		{"same-zone", tea.MouseMotionMsg{X: 1, Y: 0}, ""},
+		{"nested", tea.MouseMotionMsg{X: 4, Y: 0}, "\x1b]22;text\x1b\\"},
+		{"non-mouse", tea.KeyPressMsg{}, ""},
+		{"leave-to-plain", tea.MouseMotionMsg{X: 8, Y: 0}, "\x1b]22;default\x1b\\"},
+		{"outside", tea.MouseMotionMsg{X: 20, Y: 0}, ""},
+		{"enter-again", tea.MouseMotionMsg{X: 0, Y: 0}, "\x1b]22;pointer\x1b\\"},
+	}
+
+	for _, test := range tests {
+		if got := pointerSequence(t, zm.UpdatePointer(test.msg)); got != test.want {
+			t.Errorf("%s: got %q, want %q", test.name, got, test.want)
+		}
+	}
+
+	zm.SetEnabled(false)
+
+	if got := pointerSequence(t, zm.UpdatePointer(tea.KeyPressMsg{})); got != "\x1b]22;default\x1b\\" {
+		t.Errorf("disabled: got %q, want default pointer", got)
+	}
+
+	if got := pointerSequence(t, zm.UpdatePointer(tea.MouseMotionMsg{X: 0, Y: 0})); got != "" {
+		t.Errorf("disabled: got %q, want no change", got)
+	}
+}
+
+func TestResetPointer(t *testing.T) {
+	zm := New(WithSyncCommit(true))
+	defer zm.Close()
+
+	_ = zm.Scan(zm.MarkWith("link", "foo", WithPointer(PointerHand)))
+
+	if cmd := zm.ResetPointer(); cmd != nil {
+		t.Error("initial: got reset, want nil")
+	}
+
+	_ = zm.UpdatePointer(tea.MouseMotionMsg{X: 0, Y: 0})
+
+	if got := pointerSequence(t, zm.ResetPointer()); got != "\x1b]22;default\x1b\\" {
+		t.Errorf("hovering: got %q, want default pointer", got)
+	}
+
+	if cmd := zm.ResetPointer(); cmd != nil {
+		t.Error("reset: got reset, want nil")
+	}
+
+	if got := pointerSequence(t, zm.UpdatePointer(tea.MouseMotionMsg{X: 0, Y: 0})); got != "\x1b]22;pointer\x1b\\" {
+		t.Errorf("hovering again: got %q, want pointer", got)
+	}
+}
+
+func TestQuit(t *testing.T) {
+	zm := New(WithSyncCommit(true))
+	defer zm.Close()
+
+	_ = zm.Scan(zm.MarkWith("link", "foo", WithPointer(PointerHand)))
+
+	if _, ok := zm.Quit()().(tea.QuitMsg); !ok {
+		t.Error("default pointer: expected only tea.Quit")
+	}
+
+	_ = zm.UpdatePointer(tea.MouseMotionMsg{X: 0, Y: 0})
+
+	// The pointer should be reset before quitting.
+	if _, ok := zm.Quit()().(tea.QuitMsg); ok {
+		t.Error("changed pointer: expected the pointer to be reset before quitting")
+	}
+
+	if cmd := zm.ResetPointer(); cmd != nil {
+		t.Error("expected pointer to be reset by Quit()")
+	}
+}
@@ -5,8 +5,11 @@
 package zone
 
 import (
+	"sort"
+	"strings"
 	"unicode/utf8"
 
+	"github.com/charmbracelet/x/ansi"
 	"github.com/mattn/go-runewidth"
 )
 
@@ -17,9 +20,8 @@ const (
 type stateFn func(*scanner) stateFn
 
 type scanner struct {
-	manager   *Manager
-	enabled   bool
-	iteration int
+	manager *Manager
+	enabled bool
 
 	input string // Source input.
 	pos   int    // Current position in the input.
@@ -32,27 +34,65 @@ type scanner struct {
 
 	// tracked is the temporary location for starting markers.
 	tracked map[string]*ZoneInfo
+
+	// zones are the completed zones, which are committed to the manager once
+	// the whole input has been scanned.
+	zones []*ZoneInfo
+
+	// seen contains the IDs of completed zones, used to detect duplicates.
+	seen        map[string]bool
+	diagnostics []Diagnostic
+
+	dropped int // Number of zones with unknown markers.
 }
 
-func newScanner(m *Manager, input string, iteration int) *scanner {
+func newScanner(m *Manager, input string) *scanner {
 	return &scanner{
-		manager:   m,
-		enabled:   m.Enabled(),
-		iteration: iteration,
-		input:     input,
-		tracked:   make(map[string]*ZoneInfo),
+		manager: m,
+		enabled: m.Enabled(),
+		input:   input,
+		tracked: make(map[string]*ZoneInfo),
+		seen:    make(map[string]bool),
 	}
 }
 
+// strip returns v with all zone markers removed, without tracking any zones.
+func (m *Manager) strip(v string) string {
+	s := newScanner(m, v)
+	s.enabled = false
+	s.run()
+	return s.input
+}
+
 // run initializes the scanner and starts the state machine.
 func (s *scanner) run() {
 	for state := scanMain; state != nil; {
 		state = state(s)
 	}
+
+	// Any markers left without a matching end marker are unbalanced.
+	for _, item := range s.tracked {
+		s.diagnostics = append(s.diagnostics, Diagnostic{
+			Kind: DiagnosticUnbalanced,
+			ID:   item.name,
+			X:    item.StartX,
+			Y:    item.StartY,
+		})
+	}
+	sort.Slice(s.diagnostics, func(i, j int) bool {
+		a, b := s.diagnostics[i], s.diagnostics[j]
+		if a.Y != b.Y {
+			return a.Y < b.Y
+		}
+		if a.X != b.X {
+			return a.X < b.X
+		}
+		return a.Kind < b.Kind
+	})
 }
 
 // emit adds the current marker to the tracked map. If two markers are received,
-// it is sent back to the manager.
+// the zone is completed.
 func (s *scanner) emit() {
 	if !s.enabled {
 		// If the manager is disabled, we don't need to track anything, just strip
@@ -66,18 +106,32 @@ func (s *scanner) emit() {
 	if item, ok := s.tracked[rid]; ok {
 		// The end should be - 1, because it's the end of the encapsulation of the
 		// zone, and isn't actually taking up another space.
-		item.EndX = printableRuneWidth(s.input[s.lastNewline:s.start]) - 1
+		item.EndX = s.manager.width(s.input[s.lastNewline:s.start]) - 1
 		item.EndY = s.newlines
 
-		s.manager.setChan <- item
-
 		delete(s.tracked, rid)
+
+		if item.name == "" {
+			// Marked by another manager, or otherwise unknown.
+			s.dropped++
+			s.input = s.input[:s.start] + s.input[s.pos:]
+			s.pos = s.start
+			return
+		}
+
+		s.diagnostics = append(s.diagnostics, diagnose(item, s.seen)...)
+		s.seen[rid] = true
+		s.zones = append(s.zones, item)
 	} else {
+		name, meta := s.manager.getReverse(rid)
 		s.tracked[rid] = &ZoneInfo{
-			id:        rid,
-			iteration: s.iteration,
-			StartX:    printableRuneWidth(s.input[s.lastNewline:s.start]),
-			StartY:    s.newlines,
+			manager: s.manager,
+			id:      rid,
+			name:    name,
+			meta:    meta,
+			depth:   len(s.tracked),
+			StartX:  s.manager.width(s.input[s.lastNewline:s.start]),
+			StartY:  s.newlines,
 		}
 	}
 
@@ -143,7 +197,7 @@ func scanID(s *scanner) stateFn {
 		s.next()
 	}
 
-	if s.peek() != identEnd {
+	if s.peek() != s.manager.markerEnd {
 		return scanMain
 	}
 	s.next()
@@ -159,23 +213,90 @@ func isNumber(r rune) bool {
 	return true
 }
 
-// printableRuneWidth returns the printable cell width of the given string.
+// States used by printableRuneWidth, when tracking escape sequences.
+const (
+	ansiNone      = iota // Not in an escape sequence.
+	ansiEscape           // After an escape, before the sequence type is known.
+	ansiCSI              // In a CSI sequence, e.g. "ESC[1m".
+	ansiOSC              // In an OSC sequence, e.g. "ESC]8;;url ESC\".
+	ansiOSCEscape        // After an escape in an OSC sequence (start of ST).
+)
+
+// printableRuneWidth returns the printable cell width of the given string. It is
+// the default WidthFunc.
+// CSI sequences (e.g. colors, and zone markers) and OSC sequences (e.g. OSC 8
+// hyperlinks), terminated by either BEL or ST, are not counted.
 func printableRuneWidth(s string) int {
 	var n int
-	var ansi bool
+	state := ansiNone
 
 	for _, c := range s {
-		if c == identStart { // Start of ANSI escape sequence.
-			ansi = true
-		} else if ansi {
-			// Check if at the end of an ANSI escape sequence (terminator).
-			if (c >= 0x40 && c <= 0x5a) || (c >= 0x61 && c <= 0x7a) {
-				ansi = false
+		switch state {
+		case ansiNone:
+			if c == identStart { // Start of ANSI escape sequence.
+				state = ansiEscape
+				continue
 			}
-		} else {
 			n += runewidth.RuneWidth(c)
+		case ansiEscape:
+			switch c {
+			case identBracket:
+				state = ansiCSI
+			case ']':
+				state = ansiOSC
+			default:
+				// Two character escape sequence (e.g. "ESC7").
+				state = ansiNone
+			}
+		case ansiCSI:
+			// Check if at the end of a CSI sequence (final byte).
+			if c >= 0x40 && c <= 0x7e {
+				state = ansiNone
+			}
+		case ansiOSC:
+			switch c {
+			case '\a': // BEL terminator.
+				state = ansiNone
+			case identStart: // Start of ST terminator ("ESC\").
+				state = ansiOSCEscape
+			}
+		case ansiOSCEscape:
+			state = ansiNone
 		}
 	}
 
 	return n
 }
+
+// cut returns the cells of s from left (inclusive) to right (exclusive), like
+// ansi.Cut(), however cells are measured using the width function of the
+// manager (see WithWidthFunc()), so they match the coordinates of zones. Like
+// ansi.Cut(), all ANSI sequences are kept, so styling is preserved.
+func (m *Manager) cut(s string, left, right int) string {
+	if right <= left {
+		return ""
+	}
+
+	var b strings.Builder
+	var state byte
+	var x int
+
+	for len(s) > 0 {
+		seq, width, n, newState := ansi.DecodeSequence(s, state, nil)
+		state = newState
+		s = s[n:]
+
+		if width == 0 {
+			b.WriteString(seq)
+			continue
+		}
+
+		width = m.width(seq)
+		if x >= left && x+width <= right {
+			b.WriteString(seq)
+		}
+		x += width
+	}
+
+	return b.String()
+}
@@ -0,0 +1,199 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package zone
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Provider is implemented by anything which provides a zone manager, like
+// parent models passing a manager down to their children, Manager itself and
+// Scoped. Components should accept a Provider (or a *Manager) rather than using
+// the global functions, and resolve it with From().
+//
+// Usage example:
+//
+//	type model struct {
+//		zones *zone.Manager
+//		child child.Model
+//	}
+//
+//	func (m model) ZoneManager() *zone.Manager {
+//		return m.zones
+//	}
+//
+//	func newModel(zones *zone.Manager) model {
+//		m := model{zones: zones}
+//		m.child = child.New(m) // child calls zone.From(m).
+//		return m
+//	}
+type Provider interface {
+	ZoneManager() *Manager
+}
+
+// ZoneManager returns m, so that Manager implements Provider.
+func (m *Manager) ZoneManager() *Manager {
+	return m
+}
+
+// From returns the manager provided by v, which can be a *Manager, a Scoped, or
+// any other Provider. If v is nil (including a nil pointer), doesn't provide a
+// manager, or provides a nil manager, DefaultManager is returned instead. Like
+// the global functions, From() panics if DefaultManager is needed but wasn't
+// initialized (see NewGlobal()).
+func From(v any) *Manager {
+	switch p := v.(type) {
+	case *Manager:
+		if p != nil {
+			return p
+		}
+	case Provider:
+		if isNil(p) {
+			break
+		}
+
+		if m := p.ZoneManager(); m != nil {
+			return m
+		}
+	}
+
+	DefaultManager.checkInitialized()
+	return DefaultManager
+}
+
+// isNil returns true if v is a nil pointer, map, slice, channel or function,
+// stored in an interface.
+func isNil(v any) bool {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Chan, reflect.Func:
+		return rv.IsNil()
+	default:
+		return false
+	}
+}
+
+// Scoped is a view of a manager which prefixes all IDs with a unique prefix (see
+// NewPrefix()), so components can use short, fixed IDs without colliding with
+// other components, or other instances of the same component, and without
+// having to prefix each ID themselves. Scopes can be nested (see Scope()).
+//
+// IDs passed to the methods of Scoped are local to the scope. IDs of zones and
+// zone messages (e.g. MsgZoneClick) are the full, prefixed IDs, which can be
+// converted back with Local(). The zero value uses DefaultManager (see From()),
+// without any prefix.
+//
+// Usage example:
+//
+//	func New(zones zone.Provider) Model {
+//		return Model{zones: zone.From(zones).Scope()}
+//	}
+//
+//	func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+//		switch msg := msg.(type) {
+//		case zone.MsgZoneRelease:
+//			if id, ok := m.zones.Local(msg.ID); ok && id == "ok" {
+//				// [...]
+//			}
+//		}
+//		return m, nil
+//	}
+//
+//	func (m Model) View() string {
+//		return m.zones.Mark("ok", "[ OK ]")
+//	}
+type Scoped struct {
+	manager *Manager
+	prefix  string
+}
+
+// Scope returns a new scoped view of the manager, with a unique prefix. See
+// Scoped.
+func (m *Manager) Scope() Scoped {
+	return Scoped{manager: m, prefix: m.NewPrefix()}
+}
+
+// ZoneManager returns the underlying manager, so that Scoped implements
+// Provider.
+func (s Scoped) ZoneManager() *Manager {
+	return From(s.manager)
+}
+
+// Scope returns a new scope nested within s, so IDs are prefixed by both
+// scopes.
+func (s Scoped) Scope() Scoped {
+	m := s.ZoneManager()
+	return Scoped{manager: m, prefix: s.prefix + m.NewPrefix()}
+}
+
+// Prefix returns the prefix added to IDs of the scope.
+func (s Scoped) Prefix() string {
+	return s.prefix
+}
+
+// ID returns the full ID of id, including the prefix of the scope.
+func (s Scoped) ID(id string) string {
+	return s.prefix + id
+}
+
+// Local returns id without the prefix of the scope. If id isn't within the
+// scope, ok is false.
+func (s Scoped) Local(id string) (local string, ok bool) {
+	if !strings.HasPrefix(id, s.prefix) {
+		return "", false
+	}
+	return id[len(s.prefix):], true
+}
+
+// Mark is the same as Manager.Mark(), with id being local to the scope.
+func (s Scoped) Mark(id, v string) string {
+	return s.ZoneManager().Mark(s.ID(id), v)
+}
+
+// MarkWith is the same as Manager.MarkWith(), with id being local to the scope.
+func (s Scoped) MarkWith(id, v string, opts ...MarkOption) string {
+	return s.ZoneManager().MarkWith(s.ID(id), v, opts...)
+}
+
+// MarkFunc is the same as Manager.MarkFunc(), with id being local to the scope.
+func (s Scoped) MarkFunc(id, v string, fn HandlerFunc) string {
+	return s.ZoneManager().MarkFunc(s.ID(id), v, fn)
+}
+
+// MarkLink is the same as Manager.MarkLink(), with id being local to the scope.
+func (s Scoped) MarkLink(id, url, text string, opts ...MarkOption) string {
+	return s.ZoneManager().MarkLink(s.ID(id), url, text, opts...)
+}
+
+// Get is the same as Manager.Get(), with id being local to the scope.
+func (s Scoped) Get(id string) *ZoneInfo {
+	return s.ZoneManager().Get(s.ID(id))
+}
+
+// Clear is the same as Manager.Clear(), with id being local to the scope.
+func (s Scoped) Clear(id string) {
+	s.ZoneManager().Clear(s.ID(id))
+}
+
+// Capture is the same as Manager.Capture(), with id being local to the scope.
+func (s Scoped) Capture(id string) {
+	s.ZoneManager().Capture(s.ID(id))
+}
+
+// Captured returns the local ID of the zone which has the mouse captured. If no
+// zone has the mouse captured, or the zone isn't within the scope, ok is false.
+func (s Scoped) Captured() (id string, ok bool) {
+	captured := s.ZoneManager().Captured()
+	if captured == "" {
+		return "", false
+	}
+	return s.Local(captured)
+}
+
+// SetMenu is the same as Manager.SetMenu(), with id being local to the scope.
+func (s Scoped) SetMenu(id string, items ...MenuItem) {
+	s.ZoneManager().SetMenu(s.ID(id), items...)
+}
@@ -0,0 +1,151 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package zone
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+type testProvider struct {
+	manager *Manager
+}
+
+func (p testProvider) ZoneManager() *Manager {
+	return p.manager
+}
+
+type testPtrProvider struct {
+	manager *Manager
+}
+
+func (p *testPtrProvider) ZoneManager() *Manager {
+	return p.manager
+}
+
+func TestFrom(t *testing.T) {
+	zm := New()
+	defer zm.Close()
+
+	tests := []struct {
+		name string
+		in   any
+		want *Manager
+	}{
+		{"nil", nil, DefaultManager},
+		{"manager", zm, zm},
+		{"nil-manager", (*Manager)(nil), DefaultManager},
+		{"provider", testProvider{zm}, zm},
+		{"nil-provider", testProvider{}, DefaultManager},
+		{"ptr-provider", &testPtrProvider{zm}, zm},
+		{"typed-nil-provider", (*testPtrProvider)(nil), DefaultManager},
+		{"scoped", zm.Scope(), zm},
+		{"zero-scoped", Scoped{}, DefaultManager},
+		{"other", "foo", DefaultManager},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := From(test.in); got != test.want {
+				t.Errorf("got %p, want %p", got, test.want)
+			}
+		})
+	}
+}
+
+func TestFromUninitialized(t *testing.T) {
+	global := DefaultManager
+	DefaultManager = nil
+	defer func() { DefaultManager = global }()
+
+	for name, v := range map[string]any{
+		"nil":                nil,
+		"typed-nil-provider": (*testPtrProvider)(nil),
+		"zero-scoped":        Scoped{},
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r != "manager not initialized" {
+					t.Errorf("got %v, want panic for uninitialized manager", r)
+				}
+			}()
+
+			if s, ok := v.(Scoped); ok {
+				_ = s.Mark("foo", "bar")
+			}
+			_ = From(v)
+		})
+	}
+}
+
+func TestScoped(t *testing.T) {
+	zm := New(WithSyncCommit(true))
+	defer zm.Close()
+
+	// Two instances of the same component, using the same local IDs.
+	a, b := zm.Scope(), zm.Scope()
+	nested := a.Scope()
+
+	_ = zm.Scan(a.Mark("ok", "[a]") + " " + b.Mark("ok", "[b]") + " " + nested.Mark("ok", "[n]"))
+
+	for i, test := range []struct {
+		scope  Scoped
+		startX int
+	}{
+		{a, 0},
+		{b, 4},
+		{nested, 8},
+	} {
+		z := test.scope.Get("ok")
+		if z.IsZero() || z.StartX != test.startX {
+			t.Fatalf("scope %d: got %#v, want zone at %d", i, z, test.startX)
+		}
+
+		if z.ID() != test.scope.ID("ok") {
+			t.Errorf("scope %d: got ID %q, want %q", i, z.ID(), test.scope.ID("ok"))
+		}
+		if id, ok := test.scope.Local(z.ID()); !ok || id != "ok" {
+			t.Errorf("scope %d: got local ID %q (%v), want %q", i, id, ok, "ok")
+		}
+	}
+
+	// The nested scope is within a, but not b.
+	if id, ok := a.Local(nested.ID("ok")); !ok || id != nested.Prefix()[len(a.Prefix()):]+"ok" {
+		t.Errorf("got %q (%v) for nested ID within parent scope", id, ok)
+	}
+	if _, ok := b.Local(a.ID("ok")); ok {
+		t.Error("expected ID of another scope not to be local")
+	}
+
+	a.Capture("ok")
+	if id, ok := a.Captured(); !ok || id != "ok" {
+		t.Errorf("got captured %q (%v), want %q", id, ok, "ok")
+	}
+	if _, ok := b.Captured(); ok {
+		t.Error("expected zone captured by another scope not to be reported")
+	}
+	zm.Release()
+
+	// Zone messages of wrapped models use the full ID.
+	w := zm.Wrap(testWrapModel{})
+	w, _ = w.Update(tea.MouseReleaseMsg{X: 5, Y: 0, Button: tea.MouseLeft})
+
+	var found bool
+	for _, msg := range Unwrap(w).(testWrapModel).received {
+		if msg, ok := msg.(MsgZoneRelease); ok {
+			id, ok := b.Local(msg.ID)
+			found = found || (ok && id == "ok")
+		}
+	}
+	if !found {
+		t.Error("expected MsgZoneRelease for the zone of scope b")
+	}
+
+	a.Clear("ok")
+	if !a.Get("ok").IsZero() || b.Get("ok").IsZero() {
+		t.Error("expected only the zone of scope a to be cleared")
+	}
+}
@@ -0,0 +1,301 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package zone
+
+import (
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// DefaultSelectionStyle is the default style used to highlight selected text.
+// See Manager.SetSelectionStyle().
+var DefaultSelectionStyle = lipgloss.NewStyle().Reverse(true)
+
+// MsgZoneSelection is sent when text has been selected by dragging the mouse,
+// once the mouse button is released. See Manager.UpdateSelection().
+type MsgZoneSelection struct {
+	Text string // The selected text, without styling.
+}
+
+// cell is the position of a cell within the view.
+type cell struct {
+	x, y int
+}
+
+// before returns true if c is before o, in reading order.
+func (c cell) before(o cell) bool {
+	return c.y < o.y || (c.y == o.y && c.x < o.x)
+}
+
+// selectionState holds the state of the mouse text selection.
+type selectionState struct {
+	style   lipgloss.Style
+	frame   string // The last scanned view, with zone markers stripped.
+	pressed bool   // The left mouse button is held down.
+	active  bool   // The mouse has moved while pressed, so there is a selection.
+	anchor  cell   // Where the selection started.
+	head    cell   // Where the selection currently ends (inclusive).
+}
+
+// WithNonSelectable excludes the zone from text selection (see
+// Manager.UpdateSelection()). The contents of the zone are skipped when copying
+// selected text, and pressing the mouse within the zone doesn't start a
+// selection, which is useful for interactive elements like buttons or
+// scrollbars, which may be dragged.
+func WithNonSelectable() MarkOption {
+	return func(meta *zoneMeta) {
+		meta.nonSelectable = true
+	}
+}
+
+// SetSelectionStyle sets the style used to highlight selected text (see
+// Manager.HighlightSelection()). Defaults to DefaultSelectionStyle.
+func (m *Manager) SetSelectionStyle(style lipgloss.Style) {
+	m.selectionMu.Lock()
+	m.selection.style = style
+	m.selectionMu.Unlock()
+}
+
+// UpdateSelection tracks text selection using the mouse, across the whole
+// scanned view. Pressing the left mouse button starts a selection, dragging
+// extends it, and releasing the button completes it, returning a command which
+// sends a MsgZoneSelection with the selected text. Pressing the mouse again
+// clears the selection.
+//
+// Mouse events are only reported while the mouse button is held down when using
+// tea.MouseModeCellMotion or tea.MouseModeAllMotion. Use WithSelection() to have
+// wrapped models (see Wrap()) call UpdateSelection() automatically.
+func (m *Manager) UpdateSelection(msg tea.Msg) tea.Cmd {
+	if !m.Enabled() {
+		m.ClearSelection()
+		return nil
+	}
+
+	mouse, ok := msg.(tea.MouseMsg)
+	if !ok {
+		return nil
+	}
+
+	event := mouse.Mouse()
+	pos := cell{x: event.X, y: event.Y}
+
+	var nonSelectable bool
+	if _, ok := mouse.(tea.MouseClickMsg); ok && event.Button == tea.MouseLeft {
+		for _, zone := range m.findInBounds(mouse) {
+			if zone.meta != nil && zone.meta.nonSelectable {
+				nonSelectable = true
+				break
+			}
+		}
+	}
+
+	m.selectionMu.Lock()
+	defer m.selectionMu.Unlock()
+
+	s := &m.selection
+
+	switch mouse.(type) {
+	case tea.MouseClickMsg:
+		if event.Button != tea.MouseLeft {
+			return nil
+		}
+
+		s.active = false
+		s.pressed = !nonSelectable
+		s.anchor, s.head = pos, pos
+	case tea.MouseMotionMsg:
+		if !s.pressed {
+			return nil
+		}
+
+		s.head = pos
+		s.active = s.active || pos != s.anchor
+	case tea.MouseReleaseMsg:
+		if !s.pressed || event.Button != tea.MouseLeft {
+			return nil
+		}
+
+		s.pressed = false
+		if !s.active {
+			return nil
+		}
+
+		s.head = pos
+		if text := m.selectedText(); text != "" {
+			return func() tea.Msg { return MsgZoneSelection{Text: text} }
+		}
+	}
+
+	return nil
+}
+
+// Selection returns the currently selected text, without styling, zone markers
+// or the contents of non-selectable zones (see WithNonSelectable()). Trailing
+// whitespace is removed from each line. If nothing is selected, ok is false.
+func (m *Manager) Selection() (text string, ok bool) {
+	m.selectionMu.Lock()
+	defer m.selectionMu.Unlock()
+
+	if !m.selection.active {
+		return "", false
+	}
+
+	text = m.selectedText()
+	return text, text != ""
+}
+
+// ClearSelection clears the current selection, if any.
+func (m *Manager) ClearSelection() {
+	m.selectionMu.Lock()
+	m.selection.pressed = false
+	m.selection.active = false
+	m.selection.anchor, m.selection.head = cell{}, cell{}
+	m.selectionMu.Unlock()
+}
+
+// CopySelection returns a command which copies the currently selected text to
+// the system clipboard, using OSC 52. Note that not all terminals support OSC
+// 52. If nothing is selected, nil is returned.
+func (m *Manager) CopySelection() tea.Cmd {
+	text, ok := m.Selection()
+	if !ok {
+		return nil
+	}
+	return tea.SetClipboard(text)
+}
+
+// HighlightSelection returns view (which should be the output of Scan()) with
+// the selected text highlighted, using the selection style (see
+// SetSelectionStyle()). Non-selectable zones aren't highlighted.
+//
+// Wrapped models using WithSelection() highlight the selection automatically.
+func (m *Manager) HighlightSelection(view string) string {
+	m.selectionMu.Lock()
+	defer m.selectionMu.Unlock()
+
+	if !m.selection.active || !m.Enabled() {
+		return view
+	}
+
+	start, end := m.selection.bounds()
+	excluded := m.nonSelectable()
+
+	lines := strings.Split(view, "\n")
+	for y := max(0, start.y); y <= end.y && y < len(lines); y++ {
+		line := lines[y]
+		width := m.width(line)
+
+		var b strings.Builder
+		var last int
+		for _, span := range m.selection.spans(y, width, excluded) {
+			b.WriteString(m.cut(line, last, span[0]))
+			b.WriteString(m.selection.style.Render(ansi.Strip(m.cut(line, span[0], span[1]))))
+			last = span[1]
+		}
+		b.WriteString(m.cut(line, last, width))
+
+		lines[y] = b.String()
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// setFrame stores the last scanned view, which selected text is read from.
+func (m *Manager) setFrame(frame string) {
+	m.selectionMu.Lock()
+	m.selection.frame = frame
+	m.selectionMu.Unlock()
+}
+
+// selectedText returns the selected text from the last scanned view. The caller
+// must hold selectionMu.
+func (m *Manager) selectedText() string {
+	start, end := m.selection.bounds()
+	excluded := m.nonSelectable()
+
+	lines := strings.Split(m.selection.frame, "\n")
+	out := make([]string, 0, end.y-start.y+1)
+
+	for y := max(0, start.y); y <= end.y && y < len(lines); y++ {
+		line := lines[y]
+
+		var b strings.Builder
+		for _, span := range m.selection.spans(y, m.width(line), excluded) {
+			b.WriteString(ansi.Strip(m.cut(line, span[0], span[1])))
+		}
+
+		out = append(out, strings.TrimRight(b.String(), " "))
+	}
+
+	return strings.Join(out, "\n")
+}
+
+// nonSelectable returns the zones which are excluded from selection.
+func (m *Manager) nonSelectable() (zones []*ZoneInfo) {
+	m.zoneMu.RLock()
+	defer m.zoneMu.RUnlock()
+
+	for _, zone := range m.zones {
+		if zone.meta != nil && zone.meta.nonSelectable {
+			zones = append(zones, zone)
+		}
+	}
+	return zones
+}
+
+// bounds returns the start and end (inclusive) of the selection, in reading
+// order.
+func (s *selectionState) bounds() (start, end cell) {
+	if s.head.before(s.anchor) {
+		return s.head, s.anchor
+	}
+	return s.anchor, s.head
+}
+
+// spans returns the ranges of cells ([start, end)) which are selected on line y,
+// with the provided width, excluding cells within the excluded zones.
+func (s *selectionState) spans(y, width int, excluded []*ZoneInfo) [][2]int {
+	start, end := s.bounds()
+
+	from, to := 0, width
+	if y == start.y {
+		from = max(0, start.x)
+	}
+	if y == end.y {
+		to = min(width, end.x+1)
+	}
+
+	if from >= to {
+		return nil
+	}
+
+	spans := [][2]int{{from, to}}
+	for _, zone := range excluded {
+		if y < zone.StartY || y > zone.EndY {
+			continue
+		}
+
+		var next [][2]int
+		for _, span := range spans {
+			if zone.EndX < span[0] || zone.StartX >= span[1] {
+				next = append(next, span)
+				continue
+			}
+
+			if zone.StartX > span[0] {
+				next = append(next, [2]int{span[0], zone.StartX})
+			}
+			if zone.EndX+1 < span[1] {
+				next = append(next, [2]int{zone.EndX + 1, span[1]})
+			}
+		}
+		spans = next
+	}
+
+	return spans
+}
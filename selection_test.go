@@ -0,0 +1,152 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package zone
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestSelection(t *testing.T) {
+	zm := New()
+	defer zm.Close()
+
+	_ = zm.Scan("\x1b[1mhello\x1b[0m world  \n" + zm.MarkWith("btn", "[ok]", WithNonSelectable()) + " second\nthird line")
+	time.Sleep(100 * time.Millisecond)
+
+	if _, ok := zm.Selection(); ok {
+		t.Fatal("expected no selection initially")
+	}
+
+	_ = zm.UpdateSelection(tea.MouseClickMsg{X: 6, Y: 0, Button: tea.MouseLeft})
+
+	// Clicking without dragging doesn't select anything.
+	if cmd := zm.UpdateSelection(tea.MouseReleaseMsg{X: 6, Y: 0, Button: tea.MouseLeft}); cmd != nil {
+		t.Error("expected no command without dragging")
+	}
+
+	// Dragging backwards, from the third line to the first line.
+	_ = zm.UpdateSelection(tea.MouseClickMsg{X: 4, Y: 2, Button: tea.MouseLeft})
+	_ = zm.UpdateSelection(tea.MouseMotionMsg{X: 3, Y: 1})
+	_ = zm.UpdateSelection(tea.MouseMotionMsg{X: 6, Y: 0})
+	cmd := zm.UpdateSelection(tea.MouseReleaseMsg{X: 6, Y: 0, Button: tea.MouseLeft})
+
+	want := "world\n second\nthird"
+	if cmd == nil {
+		t.Fatal("expected selection command")
+	}
+	if msg, ok := cmd().(MsgZoneSelection); !ok || msg.Text != want {
+		t.Errorf("got %#v, want %q", msg, want)
+	}
+
+	if got, ok := zm.Selection(); !ok || got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	if zm.CopySelection() == nil {
+		t.Error("expected copy command")
+	}
+
+	zm.SetSelectionStyle(lipgloss.NewStyle().Underline(true))
+	highlighted := zm.HighlightSelection("aaaaaaaaaa\nbbbbbbbbbb")
+	if want := "aaaaaa\x1b[4;4ma\x1b[m\x1b[4;4ma\x1b[m\x1b[4;4ma\x1b[m\x1b[4;4ma\x1b[m\nbbbb"; !strings.HasPrefix(highlighted, want) {
+		t.Errorf("got %q, want selection to be highlighted", highlighted)
+	}
+
+	// Pressing within a non-selectable zone clears the selection, without
+	// starting a new one.
+	_ = zm.UpdateSelection(tea.MouseClickMsg{X: 1, Y: 1, Button: tea.MouseLeft})
+	_ = zm.UpdateSelection(tea.MouseMotionMsg{X: 8, Y: 1})
+	if cmd := zm.UpdateSelection(tea.MouseReleaseMsg{X: 8, Y: 1, Button: tea.MouseLeft}); cmd != nil {
+		t.Error("expected no selection from a non-selectable zone")
+	}
+
+	if _, ok := zm.Selection(); ok {
+		t.Error("expected selection to be cleared")
+	}
+
+	if got := zm.HighlightSelection("aaaa"); got != "aaaa" {
+		t.Errorf("got %q, want view unchanged", got)
+	}
+}
+
+func TestSelectionWidthFunc(t *testing.T) {
+	// Every rune is two cells wide.
+	zm := New(WithSyncCommit(true), WithWidthFunc(func(s string) int {
+		return 2 * utf8.RuneCountInString(ansi.Strip(s))
+	}))
+	defer zm.Close()
+
+	_ = zm.Scan("abcdef")
+
+	_ = zm.UpdateSelection(tea.MouseClickMsg{X: 2, Y: 0, Button: tea.MouseLeft})
+	_ = zm.UpdateSelection(tea.MouseMotionMsg{X: 5, Y: 0})
+	_ = zm.UpdateSelection(tea.MouseReleaseMsg{X: 5, Y: 0, Button: tea.MouseLeft})
+
+	if got, ok := zm.Selection(); !ok || got != "bc" {
+		t.Errorf("got %q, want %q", got, "bc")
+	}
+
+	zm.SetSelectionStyle(lipgloss.NewStyle().Bold(true))
+	if got, want := zm.HighlightSelection("abcdef"), "a\x1b[1mbc\x1b[mdef"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestSelectionSpans(t *testing.T) {
+	s := selectionState{anchor: cell{x: 2, y: 0}, head: cell{x: 5, y: 2}}
+	excluded := []*ZoneInfo{{StartX: 3, StartY: 1, EndX: 4, EndY: 1}}
+
+	tests := []struct {
+		y    int
+		want [][2]int
+	}{
+		{0, [][2]int{{2, 10}}},
+		{1, [][2]int{{0, 3}, {5, 10}}},
+		{2, [][2]int{{0, 6}}},
+	}
+
+	for _, test := range tests {
+		got := s.spans(test.y, 10, excluded)
+		if len(got) != len(test.want) {
+			t.Errorf("line %d: got %v, want %v", test.y, got, test.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != test.want[i] {
+				t.Errorf("line %d: got %v, want %v", test.y, got, test.want)
+			}
+		}
+	}
+}
+
+func TestWrapSelection(t *testing.T) {
+	wrapped := Wrap(testWrapModel{}, WithSelection(true))
+	_ = wrapped.View()
+	time.Sleep(100 * time.Millisecond)
+
+	wrapped, _ = wrapped.Update(tea.MouseClickMsg{X: 0, Y: 0, Button: tea.MouseLeft})
+	wrapped, _ = wrapped.Update(tea.MouseMotionMsg{X: 2, Y: 1})
+	wrapped, cmd := wrapped.Update(tea.MouseReleaseMsg{X: 2, Y: 1, Button: tea.MouseLeft})
+	defer ClearSelection()
+
+	if cmd == nil {
+		t.Fatal("expected selection and copy commands")
+	}
+
+	if got, ok := Selection(); !ok || got != "test\nfoo" {
+		t.Errorf("got %q, want %q", got, "test\nfoo")
+	}
+
+	if view := wrapped.View(); strings.HasPrefix(view.Content, "test\nfoo") {
+		t.Errorf("got %q, want selection to be highlighted", view.Content)
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package zone
+
+import (
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+)
+
+// DefaultTooltipDelay is the default delay before a tooltip is shown, after the
+// mouse starts hovering over a zone. See Manager.SetTooltipDelay().
+const DefaultTooltipDelay = 500 * time.Millisecond
+
+// DefaultTooltipStyle is the default style used to render tooltips. See
+// Manager.SetTooltipStyle().
+var DefaultTooltipStyle = lipgloss.NewStyle().
+	Border(lipgloss.RoundedBorder()).
+	Padding(0, 1)
+
+// msgTooltipShow is sent once the tooltip delay has passed.
+type msgTooltipShow struct {
+	seq int
+}
+
+// tooltipState holds the hover state used for tooltips.
+type tooltipState struct {
+	delay   time.Duration
+	style   lipgloss.Style
+	id      string // ID of the zone being hovered, which has a tooltip.
+	seq     int    // Incremented each time the hovered zone changes.
+	visible bool
+}
+
+// WithTooltip registers a tooltip for the zone, which is shown after the mouse
+// hovers over the zone for the tooltip delay (see Manager.SetTooltipDelay()).
+// If zones are nested, the innermost zone with a tooltip is used. Note that
+// hover events are only reported by the terminal when using
+// tea.MouseModeAllMotion.
+func WithTooltip(text string) MarkOption {
+	return func(meta *zoneMeta) {
+		meta.tooltip = text
+	}
+}
+
+// SetTooltipDelay sets how long the mouse has to hover over a zone before its
+// tooltip is shown. Defaults to DefaultTooltipDelay. If d is 0, tooltips are
+// shown immediately.
+func (m *Manager) SetTooltipDelay(d time.Duration) {
+	m.tooltipMu.Lock()
+	m.tooltip.delay = d
+	m.tooltipMu.Unlock()
+}
+
+// SetTooltipStyle sets the style used to render tooltips. Defaults to
+// DefaultTooltipStyle.
+func (m *Manager) SetTooltipStyle(style lipgloss.Style) {
+	m.tooltipMu.Lock()
+	m.tooltip.style = style
+	m.tooltipMu.Unlock()
+}
+
+// UpdateTooltip tracks which zone the mouse is hovering over, returning a
+// command which fires once the tooltip delay has passed. Pressing a mouse button
+// or moving to another zone hides the tooltip. All messages should be passed to
+// UpdateTooltip(), as it also needs to receive the messages from the returned
+// commands.
+//
+// Wrapped models (see Wrap()) call UpdateTooltip() automatically.
+func (m *Manager) UpdateTooltip(msg tea.Msg) tea.Cmd {
+	m.tooltipMu.Lock()
+	defer m.tooltipMu.Unlock()
+
+	if !m.Enabled() {
+		m.tooltip.id = ""
+		m.tooltip.visible = false
+		return nil
+	}
+
+	switch msg := msg.(type) {
+	case msgTooltipShow:
+		if msg.seq == m.tooltip.seq && m.tooltip.id != "" {
+			m.tooltip.visible = true
+		}
+	case tea.MouseClickMsg, tea.MouseWheelMsg:
+		// Hide until the mouse moves to another zone.
+		m.tooltip.seq++
+		m.tooltip.visible = false
+	case tea.MouseMsg:
+		var id string
+		for _, zone := range innermost(m.findInBounds(msg)) {
+			if zone.meta != nil && zone.meta.tooltip != "" {
+				id = zone.name
+				break
+			}
+		}
+
+		if id == m.tooltip.id {
+			return nil
+		}
+
+		m.tooltip.id = id
+		m.tooltip.seq++
+		m.tooltip.visible = false
+
+		if id == "" {
+			return nil
+		}
+
+		if m.tooltip.delay <= 0 {
+			m.tooltip.visible = true
+			return nil
+		}
+
+		seq := m.tooltip.seq
+		return tea.Tick(m.tooltip.delay, func(time.Time) tea.Msg {
+			return msgTooltipShow{seq: seq}
+		})
+	}
+
+	return nil
+}
+
+// Tooltip returns the tooltip of the zone the mouse is hovering over, rendered
+// and positioned next to the zone, if the tooltip delay has passed. The tooltip
+// is placed below the zone, or above it if it doesn't fit within a window of the
+// provided width and height, and shifted to stay inside the window. Use
+// Overlay.Composite() to draw it on top of the scanned view.
+//
+// Wrapped models (see Wrap()) composite the tooltip automatically.
+func (m *Manager) Tooltip(width, height int) (overlay Overlay, ok bool) {
+	m.tooltipMu.Lock()
+	id, visible, style := m.tooltip.id, m.tooltip.visible, m.tooltip.style
+	m.tooltipMu.Unlock()
+
+	if !visible || !m.Enabled() {
+		return overlay, false
+	}
+
+	zone := m.Get(id)
+	if zone.IsZero() || zone.meta == nil || zone.meta.tooltip == "" {
+		return overlay, false
+	}
+
+	overlay.Content = style.Render(zone.meta.tooltip)
+	return overlay.place(zone, width, height), true
+}
@@ -0,0 +1,96 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package zone
+
+import (
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+)
+
+func TestTooltip(t *testing.T) {
+	zm := New()
+	defer zm.Close()
+
+	zm.SetTooltipDelay(10 * time.Millisecond)
+	zm.SetTooltipStyle(lipgloss.NewStyle())
+
+	_ = zm.Scan("aaa\n" + zm.MarkWith("foo", "bbb", WithTooltip("tip")) + "\n" + zm.MarkWith("bar", "ccccc", WithTooltip("other")))
+	time.Sleep(100 * time.Millisecond)
+
+	if _, ok := zm.Tooltip(20, 5); ok {
+		t.Fatal("expected no tooltip before hovering")
+	}
+
+	cmd := zm.UpdateTooltip(tea.MouseMotionMsg{X: 1, Y: 1})
+	if cmd == nil {
+		t.Fatal("expected delay command")
+	}
+
+	if _, ok := zm.Tooltip(20, 5); ok {
+		t.Fatal("expected no tooltip before the delay")
+	}
+
+	// Moving within the same zone shouldn't restart the delay.
+	if cmd := zm.UpdateTooltip(tea.MouseMotionMsg{X: 2, Y: 1}); cmd != nil {
+		t.Error("expected no command when moving within the same zone")
+	}
+
+	_ = zm.UpdateTooltip(cmd())
+
+	overlay, ok := zm.Tooltip(20, 5)
+	if !ok {
+		t.Fatal("expected tooltip after the delay")
+	}
+
+	if overlay.Content != "tip" || overlay.X != 0 || overlay.Y != 2 {
+		t.Errorf("got %#v, want tooltip below zone", overlay)
+	}
+
+	// Not enough room below, so should flip above.
+	if overlay, _ = zm.Tooltip(20, 2); overlay.Y != 0 {
+		t.Errorf("got y %d, want 0", overlay.Y)
+	}
+
+	// Moving to another zone hides the tooltip, and stale delays are ignored.
+	cmd = zm.UpdateTooltip(tea.MouseMotionMsg{X: 4, Y: 2})
+	_ = zm.UpdateTooltip(msgTooltipShow{seq: 0})
+	if _, ok = zm.Tooltip(20, 5); ok {
+		t.Fatal("expected no tooltip after moving to another zone")
+	}
+
+	_ = zm.UpdateTooltip(cmd())
+	if overlay, ok = zm.Tooltip(3, 5); !ok || overlay.Content != "other" || overlay.X != 0 {
+		t.Errorf("got %#v, want tooltip shifted to fit window", overlay)
+	}
+
+	// Clicking hides the tooltip.
+	_ = zm.UpdateTooltip(tea.MouseClickMsg{X: 4, Y: 2})
+	if _, ok = zm.Tooltip(20, 5); ok {
+		t.Error("expected no tooltip after clicking")
+	}
+
+	// Leaving all zones hides the tooltip.
+	_ = zm.UpdateTooltip(tea.MouseMotionMsg{X: 10, Y: 0})
+	if cmd := zm.UpdateTooltip(tea.MouseMotionMsg{X: 10, Y: 0}); cmd != nil {
+		t.Error("expected no command outside of zones")
+	}
+}
+
+func TestOverlayComposite(t *testing.T) {
+	overlay := Overlay{Content: "xy", X: 1, Y: 1}
+
+	if got, want := overlay.Composite("aaaa\nbbbb\ncccc"), "aaaa\nbxyb\ncccc"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	// Overlays outside of the base should expand the result.
+	overlay = Overlay{Content: "xy", X: 3, Y: 1}
+	if got, want := overlay.Composite("aa"), "aa\n   xy"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
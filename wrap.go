@@ -0,0 +1,364 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package zone
+
+import (
+	"strconv"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// DispatchMode controls how a wrapped model (see Wrap()) delivers zone messages
+// for a given type of mouse event.
+type DispatchMode int
+
+const (
+	// DispatchAfter sends the raw mouse message to the model, followed by a zone
+	// message for each zone in bounds. This is the default.
+	DispatchAfter DispatchMode = iota
+
+	// DispatchBefore sends a zone message for each zone in bounds, followed by
+	// the raw mouse message.
+	DispatchBefore
+
+	// DispatchInstead sends a zone message for each zone in bounds. The raw mouse
+	// message is only sent if no zones are in bounds.
+	DispatchInstead
+
+	// DispatchNone only sends the raw mouse message, without any zone messages.
+	DispatchNone
+)
+
+// String returns the name of the dispatch mode.
+func (d DispatchMode) String() string {
+	switch d {
+	case DispatchAfter:
+		return "after"
+	case DispatchBefore:
+		return "before"
+	case DispatchInstead:
+		return "instead"
+	case DispatchNone:
+		return "none"
+	default:
+		return "DispatchMode(" + strconv.Itoa(int(d)) + ")"
+	}
+}
+
+// WrapOption is an option which can be provided to Wrap().
+type WrapOption func(*wrapConfig)
+
+type wrapConfig struct {
+	click   DispatchMode
+	release DispatchMode
+	wheel   DispatchMode
+	motion  DispatchMode
+	capture bool
+
+	selection    bool
+	copyOnSelect bool
+
+	inspectorKey string
+}
+
+// WithClickDispatch sets how MsgZoneClick messages are delivered, for
+// tea.MouseClickMsg events.
+func WithClickDispatch(mode DispatchMode) WrapOption {
+	return func(c *wrapConfig) {
+		c.click = mode
+	}
+}
+
+// WithReleaseDispatch sets how MsgZoneRelease messages are delivered, for
+// tea.MouseReleaseMsg events.
+func WithReleaseDispatch(mode DispatchMode) WrapOption {
+	return func(c *wrapConfig) {
+		c.release = mode
+	}
+}
+
+// WithWheelDispatch sets how MsgZoneWheel messages are delivered, for
+// tea.MouseWheelMsg events.
+func WithWheelDispatch(mode DispatchMode) WrapOption {
+	return func(c *wrapConfig) {
+		c.wheel = mode
+	}
+}
+
+// WithMotionDispatch sets how MsgZoneMotion messages are delivered, for
+// tea.MouseMotionMsg events.
+func WithMotionDispatch(mode DispatchMode) WrapOption {
+	return func(c *wrapConfig) {
+		c.motion = mode
+	}
+}
+
+// WithAutoCapture sets whether the innermost zone under a mouse press should
+// automatically capture the mouse (see Manager.Capture()) until the mouse button
+// is released. This is enabled by default.
+func WithAutoCapture(enabled bool) WrapOption {
+	return func(c *wrapConfig) {
+		c.capture = enabled
+	}
+}
+
+// WithSelection enables selecting text with the mouse (see
+// Manager.UpdateSelection()), highlighting the selection in the view. If
+// copyOnSelect is true, the selected text is also copied to the clipboard when
+// the mouse button is released (see Manager.CopySelection()).
+func WithSelection(copyOnSelect bool) WrapOption {
+	return func(c *wrapConfig) {
+		c.selection = true
+		c.copyOnSelect = copyOnSelect
+	}
+}
+
+// WithInspectorKey toggles the zone inspector (see Manager.SetInspector()) when
+// the provided key (e.g. "f12", as returned by tea.KeyPressMsg.String()) is
+// pressed. The key press isn't sent to the wrapped model.
+func WithInspectorKey(key string) WrapOption {
+	return func(c *wrapConfig) {
+		c.inspectorKey = key
+	}
+}
+
+// mode returns the dispatch mode for the provided mouse event.
+func (c *wrapConfig) mode(mouse tea.MouseMsg) DispatchMode {
+	switch mouse.(type) {
+	case tea.MouseClickMsg:
+		return c.click
+	case tea.MouseReleaseMsg:
+		return c.release
+	case tea.MouseWheelMsg:
+		return c.wheel
+	case tea.MouseMotionMsg:
+		return c.motion
+	default:
+		return DispatchNone
+	}
+}
+
+var _ tea.Model = (*wrappedModel)(nil)
+
+// wrappedModel is a tea.Model which wraps another model, scanning its view
+// output and dispatching mouse events to the zone manager.
+type wrappedModel struct {
+	manager *Manager
+	config  *wrapConfig
+	model   tea.Model
+
+	// Window size, used to position overlays.
+	width  int
+	height int
+}
+
+// Wrap returns model wrapped in a tea.Model which automatically handles zone
+// scanning and dispatching, which means you don't have to call Scan() yourself.
+// The returned model should be passed to tea.NewProgram(), in place of your
+// root model.
+//
+// The wrapped model will:
+//   - Scan the content of the view returned by model on every frame, stripping
+//     zone markers from the output (see ScanView()).
+//   - Enable cell motion mouse tracking on the view, if no mouse mode is set.
+//   - Send a typed zone message (MsgZoneClick, MsgZoneRelease, MsgZoneWheel or
+//     MsgZoneMotion) to model for each zone that is in the bounds of a mouse
+//     event, in alphabetical sorted order of the ID. By default, these are sent
+//     after the raw mouse message, which can be changed per mouse event type
+//     with WithClickDispatch(), WithReleaseDispatch(), WithWheelDispatch() and
+//     WithMotionDispatch(). Mouse wheel events also include a MsgZoneScroll, if
+//     a scrollable zone is under the mouse (see RouteScroll()).
+//   - Invoke the handlers registered with zones in bounds of a mouse event (see
+//     Dispatch()).
+//   - Capture the mouse for the innermost zone under a mouse press, once the
+//     press is sent to all zones in bounds, until the mouse button is released
+//     or the terminal loses focus (see Manager.Capture()). The release is sent
+//     to all zones in bounds, as well as the captured zone. This can be disabled
+//     with WithAutoCapture(false).
+//   - Update the shape of the mouse pointer when hovering over zones with a
+//     pointer shape (see WithPointer() and UpdatePointer()). Use Quit() to
+//     restore the default pointer shape when quitting.
+//   - Draw tooltips on top of the view, when hovering over zones with a tooltip
+//     (see WithTooltip() and Manager.Tooltip()).
+//   - Open context menus on right click, and draw them on top of the view (see
+//     Manager.SetMenu()). While a menu is open, events handled by the menu are
+//     not sent to model.
+//   - If enabled with WithSelection(), track and highlight text selected with
+//     the mouse, sending a MsgZoneSelection to model once selected.
+//   - Track the mouse for the zone inspector (see Manager.SetInspector()), and
+//     toggle it with the key provided to WithInspectorKey(), if any.
+//
+// Use Unwrap() to retrieve the original model, e.g. from the final model returned
+// by tea.Program.Run().
+func (m *Manager) Wrap(model tea.Model, opts ...WrapOption) tea.Model {
+	if w, ok := model.(wrappedModel); ok {
+		model = w.model
+	}
+
+	config := &wrapConfig{capture: true}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	return wrappedModel{manager: m, config: config, model: model}
+}
+
+// Unwrap returns the model which was wrapped with Wrap(). If model wasn't
+// wrapped, it is returned as-is.
+func Unwrap(model tea.Model) tea.Model {
+	if w, ok := model.(wrappedModel); ok {
+		return w.model
+	}
+	return model
+}
+
+// ScanView is the same as Scan(), however it scans the content of the provided
+// view, returning the view with the zone markers stripped. If the view has no
+// mouse mode set, cell motion tracking will be enabled, as zones are only useful
+// when mouse events are received.
+func (m *Manager) ScanView(view tea.View) tea.View {
+	view.Content = m.Scan(view.Content)
+
+	if view.MouseMode == tea.MouseModeNone {
+		view.MouseMode = tea.MouseModeCellMotion
+	}
+
+	return view
+}
+
+func (w wrappedModel) Init() tea.Cmd {
+	return w.model.Init()
+}
+
+func (w wrappedModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case msgTooltipShow:
+		return w, w.manager.UpdateTooltip(msg)
+	case tea.WindowSizeMsg:
+		w.width, w.height = msg.Width, msg.Height
+	case tea.BlurMsg:
+		// The release of a mouse button may be lost while the terminal isn't
+		// focused.
+		w.manager.Release()
+	case tea.KeyPressMsg:
+		if w.config.inspectorKey != "" && msg.String() == w.config.inspectorKey {
+			w.manager.SetInspector(!w.manager.Inspecting())
+			return w, nil
+		}
+	}
+
+	w.manager.UpdateInspector(msg)
+
+	if cmd, handled := w.manager.UpdateMenu(msg); handled {
+		if mouse, ok := msg.(tea.MouseMsg); ok {
+			w.manager.reportDispatch(DispatchInfo{Event: mouse, Mode: w.config.mode(mouse), Menu: true})
+		}
+		return w, tea.Batch(cmd, w.manager.UpdatePointer(msg))
+	}
+
+	model, cmd := w.dispatch(msg)
+
+	// Run after the update, so the pointer and tooltips are also reset when the
+	// manager is disabled during the update.
+	return model, tea.Batch(
+		cmd,
+		w.manager.UpdatePointer(msg),
+		w.manager.UpdateTooltip(msg),
+		w.updateSelection(msg),
+	)
+}
+
+// updateSelection updates the selection if enabled, copying the selected text
+// if copyOnSelect is enabled.
+func (w wrappedModel) updateSelection(msg tea.Msg) tea.Cmd {
+	if !w.config.selection {
+		return nil
+	}
+
+	cmd := w.manager.UpdateSelection(msg)
+	if cmd == nil || !w.config.copyOnSelect {
+		return cmd
+	}
+
+	return tea.Batch(cmd, w.manager.CopySelection())
+}
+
+// dispatch sends msg to the wrapped model, including any zone messages if msg
+// is a mouse event.
+func (w wrappedModel) dispatch(msg tea.Msg) (tea.Model, tea.Cmd) {
+	mouse, ok := msg.(tea.MouseMsg)
+	if !ok {
+		return w.update(msg)
+	}
+
+	if w.config.capture {
+		w.manager.releaseStale(mouse)
+
+		if _, ok := mouse.(tea.MouseReleaseMsg); ok {
+			defer w.manager.Release()
+		}
+	}
+
+	handlerCmd, handlers := w.manager.dispatch(mouse)
+
+	mode := w.config.mode(mouse)
+
+	var msgs []tea.Msg
+	if mode != DispatchNone {
+		msgs = w.manager.zoneMsgs(mouse)
+	}
+	w.manager.reportDispatch(DispatchInfo{Event: mouse, Mode: mode, Messages: len(msgs), Handlers: handlers})
+
+	// Capture after dispatching, so the press reaches all zones in bounds, and
+	// only the following events are routed to the captured zone.
+	if w.config.capture {
+		w.manager.capturePress(mouse)
+	}
+
+	switch mode {
+	case DispatchNone:
+		msgs = append(msgs, msg)
+	case DispatchBefore:
+		msgs = append(msgs, msg)
+	case DispatchInstead:
+		if len(msgs) == 0 {
+			msgs = append(msgs, msg)
+		}
+	default:
+		msgs = append([]tea.Msg{msg}, msgs...)
+	}
+
+	model, cmd := w.update(msgs...)
+	return model, tea.Batch(handlerCmd, cmd)
+}
+
+// update sends each message to the wrapped model, in order, batching the
+// resulting commands.
+func (w wrappedModel) update(msgs ...tea.Msg) (tea.Model, tea.Cmd) {
+	cmds := make([]tea.Cmd, len(msgs))
+	for i, msg := range msgs {
+		w.model, cmds[i] = w.model.Update(msg)
+	}
+
+	return w, tea.Batch(cmds...)
+}
+
+func (w wrappedModel) View() tea.View {
+	view := w.manager.ScanView(w.model.View())
+
+	if w.config.selection {
+		view.Content = w.manager.HighlightSelection(view.Content)
+	}
+
+	if overlay, ok := w.manager.Tooltip(w.width, w.height); ok {
+		view.Content = overlay.Composite(view.Content)
+	}
+
+	if overlay, ok := w.manager.Menu(); ok {
+		view.Content = overlay.Composite(view.Content)
+	}
+
+	return view
+}
@@ -0,0 +1,137 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package zone
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+var _ tea.Model = (*testWrapModel)(nil)
+
+type testWrapModel struct {
+	received []tea.Msg
+}
+
+func (m testWrapModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m testWrapModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	m.received = append(m.received, msg)
+	return m, nil
+}
+
+func (m testWrapModel) View() tea.View {
+	return tea.NewView("test\nfoo\naaa " + MarkWith("foo", "bar\ntest123456789", WithPayload(42)) + " aaa\nbaz")
+}
+
+func TestWrapView(t *testing.T) {
+	wrapped := Wrap(testWrapModel{})
+
+	view := wrapped.View()
+	if want := "test\nfoo\naaa bar\ntest123456789 aaa\nbaz"; view.Content != want {
+		t.Errorf("got %q, want %q", view.Content, want)
+	}
+
+	if view.MouseMode != tea.MouseModeCellMotion {
+		t.Errorf("got mouse mode %v, want %v", view.MouseMode, tea.MouseModeCellMotion)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	if xy := Get("foo"); xy.IsZero() {
+		t.Error("id not found")
+	}
+}
+
+func TestWrapUpdate(t *testing.T) {
+	wrapped := Wrap(testWrapModel{})
+	_ = wrapped.View()
+	time.Sleep(100 * time.Millisecond)
+
+	wrapped, _ = wrapped.Update(tea.MouseMotionMsg{X: 4, Y: 2})
+
+	m, ok := Unwrap(wrapped).(testWrapModel)
+	if !ok {
+		t.Fatalf("got %T, want testWrapModel", Unwrap(wrapped))
+	}
+
+	if len(m.received) != 2 {
+		t.Fatalf("got %d messages, want 2", len(m.received))
+	}
+
+	if _, ok := m.received[0].(tea.MouseMotionMsg); !ok {
+		t.Errorf("got %T, want tea.MouseMotionMsg", m.received[0])
+	}
+
+	evt, ok := m.received[1].(MsgZoneMotion)
+	if !ok {
+		t.Fatalf("got %T, want MsgZoneMotion", m.received[1])
+	}
+
+	if evt.ID != "foo" || evt.Zone.id != Get("foo").id {
+		t.Errorf("got id %q, want %q", evt.ID, "foo")
+	}
+
+	if evt.Payload != 42 {
+		t.Errorf("got payload %v, want %v", evt.Payload, 42)
+	}
+
+	if evt.X != 0 || evt.Y != 0 {
+		t.Errorf("got relative position %d,%d, want 0,0", evt.X, evt.Y)
+	}
+}
+
+func TestWrapDispatchMode(t *testing.T) {
+	tests := []struct {
+		name  string
+		opts  []WrapOption
+		msg   tea.MouseMsg
+		types []string
+	}{
+		{"click-after", nil, tea.MouseClickMsg{X: 4, Y: 2}, []string{"tea.MouseClickMsg", "zone.MsgZoneClick"}},
+		{"click-before", []WrapOption{WithClickDispatch(DispatchBefore)}, tea.MouseClickMsg{X: 4, Y: 2}, []string{"zone.MsgZoneClick", "tea.MouseClickMsg"}},
+		{"release-instead", []WrapOption{WithReleaseDispatch(DispatchInstead)}, tea.MouseReleaseMsg{X: 4, Y: 2}, []string{"zone.MsgZoneRelease"}},
+		{"release-instead-miss", []WrapOption{WithReleaseDispatch(DispatchInstead)}, tea.MouseReleaseMsg{X: 0, Y: 0}, []string{"tea.MouseReleaseMsg"}},
+		{"wheel-none", []WrapOption{WithWheelDispatch(DispatchNone)}, tea.MouseWheelMsg{X: 4, Y: 2}, []string{"tea.MouseWheelMsg"}},
+		{"motion-miss", nil, tea.MouseMotionMsg{X: 0, Y: 0}, []string{"tea.MouseMotionMsg"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			wrapped := Wrap(testWrapModel{}, test.opts...)
+			_ = wrapped.View()
+			time.Sleep(15 * time.Millisecond)
+
+			wrapped, _ = wrapped.Update(test.msg)
+			m := Unwrap(wrapped).(testWrapModel)
+
+			if len(m.received) != len(test.types) {
+				t.Fatalf("got %d messages, want %d", len(m.received), len(test.types))
+			}
+
+			for i, msg := range m.received {
+				if got := fmt.Sprintf("%T", msg); got != test.types[i] {
+					t.Errorf("message %d: got %s, want %s", i, got, test.types[i])
+				}
+			}
+		})
+	}
+}
+
+func TestUnwrap(t *testing.T) {
+	m := testWrapModel{}
+
+	if _, ok := Unwrap(m).(testWrapModel); !ok {
+		t.Error("expected unwrapped model to be returned as-is")
+	}
+
+	if _, ok := Unwrap(Wrap(Wrap(m))).(testWrapModel); !ok {
+		t.Error("expected double wrapped model to unwrap to original")
+	}
+}
@@ -8,8 +8,11 @@ import tea "charm.land/bubbletea/v2"
 
 // ZoneInfo holds information about the start and end positions of a zone.
 type ZoneInfo struct { // nolint:revive
-	id        string // rid of the zone.
-	iteration int    // The iteration of the zone, used for cleaning up old zones.
+	manager *Manager  // The manager which scanned the zone.
+	id      string    // rid of the zone.
+	name    string    // User provided ID of the zone.
+	meta    *zoneMeta // Additional information provided when marking the zone.
+	depth   int       // The number of zones this zone is nested within.
 
 	StartX int // StartX is the x coordinate of the top left cell of the zone (with 0 basis).
 	StartY int // StartY is the y coordinate of the top left cell of the zone (with 0 basis).
@@ -26,6 +29,25 @@ func (z *ZoneInfo) IsZero() bool {
 	return z.id == ""
 }
 
+// ID returns the ID which was provided when marking the zone. If the zone is not
+// known, it returns an empty string.
+func (z *ZoneInfo) ID() string {
+	if z.IsZero() {
+		return ""
+	}
+	return z.name
+}
+
+// Payload returns the payload which was provided when marking the zone (see
+// WithPayload()). If the zone is not known, or no payload was provided, it
+// returns nil.
+func (z *ZoneInfo) Payload() any {
+	if z.IsZero() || z.meta == nil {
+		return nil
+	}
+	return z.meta.payload
+}
+
 // InBounds returns true if the mouse event was in the bounds of the zones
 // coordinates. If the zone is not known, it returns false. It calculates this
 // using a box between the start and end coordinates. If you're looking to check
@@ -52,14 +74,39 @@ func (z *ZoneInfo) InBounds(msg tea.MouseMsg) bool {
 }
 
 // Pos returns the coordinates of the mouse event relative to the zone, with a
-// basis of (0, 0) being the top left cell of the zone. If the zone is not known,
+// basis of (0, 0) being the top left cell of the zone. While the zone has the
+// mouse captured (see Manager.Capture()), the coordinates are returned even if
+// the mouse event is outside of the bounds of the zone, which means they may be
+// negative, or larger than the zone itself. Otherwise, if the zone is not known,
 // or the mouse event is not in the bounds of the zone, this will return (-1, -1).
+// As (-1, -1) is also a valid position while captured, use RelativePos() to
+// tell them apart.
 func (z *ZoneInfo) Pos(msg tea.MouseMsg) (x, y int) {
-	if z.IsZero() || !z.InBounds(msg) {
+	x, y, ok := z.RelativePos(msg)
+	if !ok {
 		return -1, -1
 	}
+	return x, y
+}
+
+// RelativePos is the same as Pos(), however ok is false if the zone is not
+// known, or the mouse event isn't in the bounds of the zone and the zone doesn't
+// have the mouse captured, instead of returning (-1, -1).
+func (z *ZoneInfo) RelativePos(msg tea.MouseMsg) (x, y int, ok bool) {
+	if !z.tracks(msg) {
+		return 0, 0, false
+	}
 
 	event := msg.Mouse()
 
-	return event.X - z.StartX, event.Y - z.StartY
+	return event.X - z.StartX, event.Y - z.StartY, true
+}
+
+// tracks returns true if the mouse event is in the bounds of the zone, or the
+// zone has the mouse captured.
+func (z *ZoneInfo) tracks(msg tea.MouseMsg) bool {
+	if z.IsZero() {
+		return false
+	}
+	return z.InBounds(msg) || (z.manager != nil && z.manager.Captured() == z.name)
 }
@@ -22,12 +22,11 @@ func TestValidPosition(t *testing.T) {
 
 	if xy.StartX != 4 || xy.StartY != 2 || xy.EndX != 12 || xy.EndY != 3 {
 		t.Errorf("got %#v, want %#v", xy, &ZoneInfo{
-			id:        xy.id,
-			iteration: xy.iteration,
-			StartX:    4,
-			StartY:    2,
-			EndX:      12,
-			EndY:      3,
+			id:     xy.id,
+			StartX: 4,
+			StartY: 2,
+			EndX:   12,
+			EndY:   3,
 		})
 	}
 }
@@ -0,0 +1,285 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+// Package zonetest provides helpers for driving mouse interactions against
+// models using zones in unit tests, without having to compute the coordinates
+// of each zone by hand.
+//
+// A Harness wraps the model (see zone.Wrap()), renders and scans its view, and
+// synthesizes mouse events at the center of zones, feeding them to the model's
+// Update() method. The view is rendered again after each message, as it would be
+// by a running program.
+//
+// Usage example:
+//
+//	func TestModel(t *testing.T) {
+//		zm := zone.New()
+//		defer zm.Close()
+//
+//		h := zonetest.New(t, zm, newModel(zm))
+//		h.ClickZone("confirm")
+//
+//		if m := h.Model().(model); !m.confirmed {
+//			t.Error("expected confirmation")
+//		}
+//	}
+package zonetest
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	zone "github.com/lrstanley/bubblezone/v2"
+)
+
+// update is used to rewrite golden files, see Harness.RequireGolden().
+var update = flag.Bool("zonetest.update", false, "rewrite zonetest golden files")
+
+// Harness drives mouse interactions against a model. See New().
+type Harness struct {
+	tb      testing.TB
+	manager *zone.Manager
+	model   tea.Model
+	view    tea.View
+}
+
+// New returns a new harness for model, which is wrapped using the provided
+// manager and wrap options, and rendered once.
+func New(tb testing.TB, manager *zone.Manager, model tea.Model, opts ...zone.WrapOption) *Harness {
+	tb.Helper()
+
+	h := &Harness{
+		tb:      tb,
+		manager: manager,
+		model:   manager.Wrap(model, opts...),
+	}
+	h.Render()
+
+	return h
+}
+
+// Manager returns the zone manager used by the harness.
+func (h *Harness) Manager() *zone.Manager {
+	return h.manager
+}
+
+// Model returns the current (unwrapped) model.
+func (h *Harness) Model() tea.Model {
+	return zone.Unwrap(h.model)
+}
+
+// View returns the last rendered view, with zone markers stripped.
+func (h *Harness) View() tea.View {
+	return h.view
+}
+
+// Render renders and scans the view of the model, waiting until all zones have
+// been stored. This is done automatically after each message, so it's only
+// needed if the model changes outside of Update().
+func (h *Harness) Render() tea.View {
+	h.view = h.model.View()
+	h.manager.Flush()
+	return h.view
+}
+
+// Send sends each message to the model, in order, rendering the view after each
+// message, returning the batched resulting commands. Commands aren't executed,
+// see Exec().
+func (h *Harness) Send(msgs ...tea.Msg) tea.Cmd {
+	cmds := make([]tea.Cmd, 0, len(msgs))
+	for _, msg := range msgs {
+		var cmd tea.Cmd
+		h.model, cmd = h.model.Update(msg)
+		cmds = append(cmds, cmd)
+		h.Render()
+	}
+	return tea.Batch(cmds...)
+}
+
+// Exec executes cmd, sending the resulting messages to the model (see Send()).
+// Batched and sequenced commands (tea.Batch() and tea.Sequence()) are executed
+// in order, as are commands resulting from those messages. Commands nested more
+// than maxExecDepth levels deep (e.g. a model which keeps returning commands)
+// fail the test, to prevent loops. Note that commands which wait (e.g.
+// tea.Tick()) block until they complete.
+func (h *Harness) Exec(cmd tea.Cmd) {
+	h.tb.Helper()
+	h.exec(cmd, 0)
+}
+
+// maxExecDepth is the maximum depth of commands executed by Exec().
+const maxExecDepth = 10
+
+func (h *Harness) exec(cmd tea.Cmd, depth int) {
+	h.tb.Helper()
+
+	if cmd == nil {
+		return
+	}
+
+	if depth > maxExecDepth {
+		h.tb.Fatalf("zonetest: commands nested more than %d levels deep, the model may be looping", maxExecDepth)
+	}
+
+	msg := cmd()
+	if msg == nil {
+		return
+	}
+
+	// tea.BatchMsg, and the unexported message of tea.Sequence(), which are both
+	// slices of commands.
+	if rv := reflect.ValueOf(msg); rv.Kind() == reflect.Slice && rv.Type().Elem() == cmdType {
+		for i := range rv.Len() {
+			cmd, _ := rv.Index(i).Interface().(tea.Cmd)
+			h.exec(cmd, depth+1)
+		}
+		return
+	}
+
+	h.exec(h.Send(msg), depth+1)
+}
+
+// cmdType is the type of tea.Cmd.
+var cmdType = reflect.TypeFor[tea.Cmd]()
+
+// Resize sends a tea.WindowSizeMsg to the model.
+func (h *Harness) Resize(width, height int) tea.Cmd {
+	return h.Send(tea.WindowSizeMsg{Width: width, Height: height})
+}
+
+// RequireZoneVisible fails the test if the zone with the provided ID isn't in
+// the last rendered view, returning the zone otherwise.
+func (h *Harness) RequireZoneVisible(id string) *zone.ZoneInfo {
+	h.tb.Helper()
+
+	z := h.manager.Get(id)
+	if z.IsZero() {
+		h.tb.Fatalf("zonetest: zone %q is not visible", id)
+	}
+	return z
+}
+
+// RequireZoneHidden fails the test if the zone with the provided ID is in the
+// last rendered view.
+func (h *Harness) RequireZoneHidden(id string) {
+	h.tb.Helper()
+
+	if z := h.manager.Get(id); !z.IsZero() {
+		h.tb.Fatalf("zonetest: zone %q is visible at (%d, %d)-(%d, %d)", id, z.StartX, z.StartY, z.EndX, z.EndY)
+	}
+}
+
+// Center returns the coordinates of the center cell of the zone with the
+// provided ID, failing the test if the zone isn't visible.
+func (h *Harness) Center(id string) (x, y int) {
+	h.tb.Helper()
+
+	z := h.RequireZoneVisible(id)
+	return z.StartX + (z.EndX-z.StartX)/2, z.StartY + (z.EndY-z.StartY)/2
+}
+
+// ClickZone sends a left mouse button press and release at the center of the
+// zone with the provided ID.
+func (h *Harness) ClickZone(id string) tea.Cmd {
+	h.tb.Helper()
+	return h.ClickZoneWith(id, tea.MouseLeft)
+}
+
+// ClickZoneWith sends a press and release of the provided mouse button at the
+// center of the zone with the provided ID.
+func (h *Harness) ClickZoneWith(id string, button tea.MouseButton) tea.Cmd {
+	h.tb.Helper()
+
+	x, y := h.Center(id)
+	return tea.Batch(
+		h.Send(tea.MouseClickMsg{X: x, Y: y, Button: button}),
+		h.Send(tea.MouseReleaseMsg{X: x, Y: y, Button: button}),
+	)
+}
+
+// HoverZone sends a mouse motion event, without any buttons pressed, at the
+// center of the zone with the provided ID.
+func (h *Harness) HoverZone(id string) tea.Cmd {
+	h.tb.Helper()
+
+	x, y := h.Center(id)
+	return h.Send(tea.MouseMotionMsg{X: x, Y: y})
+}
+
+// DragZone presses the left mouse button at the center of the zone with the
+// from ID, moves the mouse to the center of the zone with the to ID, and
+// releases the button. Both zones must be visible when the drag starts.
+func (h *Harness) DragZone(from, to string) tea.Cmd {
+	h.tb.Helper()
+
+	fx, fy := h.Center(from)
+	tx, ty := h.Center(to)
+
+	return tea.Batch(
+		h.Send(tea.MouseClickMsg{X: fx, Y: fy, Button: tea.MouseLeft}),
+		h.Send(tea.MouseMotionMsg{X: tx, Y: ty, Button: tea.MouseLeft}),
+		h.Send(tea.MouseReleaseMsg{X: tx, Y: ty, Button: tea.MouseLeft}),
+	)
+}
+
+// WheelZone sends a mouse wheel event with the provided wheel button (e.g.
+// tea.MouseWheelDown) at the center of the zone with the provided ID.
+func (h *Harness) WheelZone(id string, button tea.MouseButton) tea.Cmd {
+	h.tb.Helper()
+
+	x, y := h.Center(id)
+	return h.Send(tea.MouseWheelMsg{X: x, Y: y, Button: button})
+}
+
+// RequireGolden compares the annotated last rendered view (see
+// zone.Manager.Annotate()) against the golden file testdata/<name>.golden,
+// failing the test with the first differing line if they don't match. Run the
+// tests with -zonetest.update to write the golden file instead.
+func (h *Harness) RequireGolden(name string) {
+	h.tb.Helper()
+
+	got := h.manager.Annotate(h.view.Content)
+	path := filepath.Join("testdata", name+".golden")
+
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			h.tb.Fatalf("zonetest: creating golden file directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			h.tb.Fatalf("zonetest: writing golden file: %v", err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		h.tb.Fatalf("zonetest: reading golden file (run with -zonetest.update to create it): %v", err)
+	}
+
+	if got == string(want) {
+		return
+	}
+
+	gotLines := strings.Split(got, "\n")
+	wantLines := strings.Split(string(want), "\n")
+
+	for i := range max(len(gotLines), len(wantLines)) {
+		var g, w string
+		if i < len(gotLines) {
+			g = gotLines[i]
+		}
+		if i < len(wantLines) {
+			w = wantLines[i]
+		}
+
+		if g != w {
+			h.tb.Fatalf("zonetest: %s differs from golden file at line %d:\n  got:  %q\n  want: %q\n\nfull output:\n%s", path, i+1, g, w, got)
+		}
+	}
+}
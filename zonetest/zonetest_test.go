@@ -0,0 +1,178 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package zonetest
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	zone "github.com/lrstanley/bubblezone/v2"
+)
+
+type testModel struct {
+	manager *zone.Manager
+	events  []string
+	hidden  bool
+}
+
+func (m testModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m testModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case zone.MsgZoneRelease:
+		m.events = append(m.events, "release:"+msg.ID)
+		if msg.ID == "hide" {
+			m.hidden = true
+			return m, func() tea.Msg { return zone.MsgZoneClick{} }
+		}
+	case zone.MsgZoneMotion:
+		m.events = append(m.events, "motion:"+msg.ID)
+	case zone.MsgZoneScroll:
+		m.events = append(m.events, "scroll:"+msg.ID)
+	case tea.WindowSizeMsg:
+		m.events = append(m.events, "resize")
+	}
+	return m, nil
+}
+
+func (m testModel) View() tea.View {
+	view := "header\n  " + m.manager.Mark("ok", "[ OK ]") + "\n" + m.manager.MarkWith("list", "a\nb\nc", zone.WithScrollable(nil))
+	if !m.hidden {
+		view += "\n" + m.manager.Mark("hide", "hide")
+	}
+	return tea.NewView(view)
+}
+
+func TestHarness(t *testing.T) {
+	zm := zone.New()
+	defer zm.Close()
+
+	h := New(t, zm, testModel{manager: zm})
+
+	if x, y := h.Center("ok"); x != 4 || y != 1 {
+		t.Errorf("got center (%d, %d), want (4, 1)", x, y)
+	}
+
+	h.ClickZone("ok")
+	h.HoverZone("ok")
+	h.WheelZone("list", tea.MouseWheelDown)
+	// The press captures the mouse, so events while dragging are only sent to the
+	// zone the drag started in, and the release to it and the zone under the
+	// mouse.
+	h.DragZone("ok", "list")
+	h.ClickZone("hide")
+	h.Resize(80, 24)
+
+	want := []string{
+		"release:ok",
+		"motion:ok",
+		"scroll:list",
+		"motion:ok",
+		"release:list",
+		"release:ok",
+		"release:hide",
+		"resize",
+	}
+
+	events := h.Model().(testModel).events
+	if len(events) != len(want) {
+		t.Fatalf("got events %v, want %v", events, want)
+	}
+	for i := range want {
+		if events[i] != want[i] {
+			t.Errorf("event %d: got %q, want %q", i, events[i], want[i])
+		}
+	}
+
+	h.RequireZoneVisible("ok")
+	h.RequireZoneHidden("hide")
+
+	if got := h.View().Content; got != "header\n  [ OK ]\na\nb\nc" {
+		t.Errorf("got view %q", got)
+	}
+}
+
+func TestHarnessExec(t *testing.T) {
+	zm := zone.New()
+	defer zm.Close()
+
+	h := New(t, zm, testModel{manager: zm})
+	h.Exec(h.ClickZone("hide"))
+
+	// The command returned from clicking "hide" sends an empty MsgZoneClick,
+	// which isn't recorded, but must not be lost or loop.
+	if events := h.Model().(testModel).events; len(events) != 1 || events[0] != "release:hide" {
+		t.Errorf("got events %v", events)
+	}
+}
+
+// fatalTB records calls to Fatalf(), stopping the goroutine like testing.T.
+type fatalTB struct {
+	testing.TB
+	failed string
+}
+
+func (tb *fatalTB) Helper() {}
+
+func (tb *fatalTB) Fatalf(format string, args ...any) {
+	tb.failed = fmt.Sprintf(format, args...)
+	runtime.Goexit()
+}
+
+// loopModel returns a command for every message, which never ends.
+type loopModel struct {
+	testModel
+}
+
+func (m loopModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	return m, func() tea.Msg { return "again" }
+}
+
+func TestHarnessExecLoop(t *testing.T) {
+	zm := zone.New()
+	defer zm.Close()
+
+	tb := &fatalTB{TB: t}
+	h := New(tb, zm, loopModel{testModel{manager: zm}})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		h.Exec(func() tea.Msg { return "start" })
+	}()
+	<-done
+
+	if !strings.Contains(tb.failed, "levels deep") {
+		t.Errorf("got failure %q, want the loop to fail the test", tb.failed)
+	}
+}
+
+func TestHarnessExecSequence(t *testing.T) {
+	zm := zone.New()
+	defer zm.Close()
+
+	h := New(t, zm, testModel{manager: zm})
+	h.Exec(tea.Sequence(
+		func() tea.Msg { return tea.WindowSizeMsg{Width: 80, Height: 24} },
+		tea.Batch(func() tea.Msg { return tea.WindowSizeMsg{Width: 40, Height: 12} }),
+	))
+
+	if events := h.Model().(testModel).events; len(events) != 2 || events[0] != "resize" || events[1] != "resize" {
+		t.Errorf("got events %v, want [resize resize]", events)
+	}
+}
+
+func TestHarnessRequireGolden(t *testing.T) {
+	zm := zone.New()
+	defer zm.Close()
+
+	h := New(t, zm, testModel{manager: zm})
+	h.RequireGolden("harness")
+}